@@ -0,0 +1,165 @@
+package callback
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultDownlinkTimeout is the time given to the bidir function to provide
+// the downlink data, leaving a margin before the Sigfox backend gives up waiting.
+const DefaultDownlinkTimeout = 15 * time.Second
+
+// DownlinkData is the 8-byte payload sent to a device in answer to a DATA BIDIR callback.
+type DownlinkData [8]byte
+
+// ParseDownlinkData parses the 16 hexadecimal characters of a downlink payload.
+func ParseDownlinkData(s string) (DownlinkData, error) {
+	var d DownlinkData
+	if len(s) != 2*len(d) {
+		return d, fmt.Errorf("downlink data must be %d bytes long, got %q", len(d), s)
+	}
+	if _, err := hex.Decode(d[:], []byte(s)); err != nil {
+		return d, fmt.Errorf("invalid downlink data %q: %v", s, err)
+	}
+	return d, nil
+}
+
+// NewDownlinkData returns the downlink payload holding b, which must be 8 bytes long.
+func NewDownlinkData(b []byte) (DownlinkData, error) {
+	var d DownlinkData
+	if len(b) != len(d) {
+		return d, fmt.Errorf("downlink data must be %d bytes long, got %d", len(d), len(b))
+	}
+	copy(d[:], b)
+	return d, nil
+}
+
+// String returns the hexadecimal form expected by the Sigfox backend.
+func (d DownlinkData) String() string {
+	return strings.ToUpper(hex.EncodeToString(d[:]))
+}
+
+// DownlinkQueue holds the downlink payloads waiting for their device to request one.
+// It is safe for concurrent use.
+type DownlinkQueue struct {
+	mu     sync.Mutex
+	queues map[string][]DownlinkData
+}
+
+// NewDownlinkQueue returns an empty queue.
+func NewDownlinkQueue() *DownlinkQueue {
+	return &DownlinkQueue{queues: make(map[string][]DownlinkData)}
+}
+
+// Push appends a payload to the queue of a device.
+func (q *DownlinkQueue) Push(deviceID string, data DownlinkData) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.queues[deviceID] = append(q.queues[deviceID], data)
+}
+
+// Pop removes and returns the oldest payload queued for a device.
+func (q *DownlinkQueue) Pop(deviceID string) (DownlinkData, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	queue := q.queues[deviceID]
+	if len(queue) == 0 {
+		return DownlinkData{}, false
+	}
+
+	data := queue[0]
+	if len(queue) == 1 {
+		delete(q.queues, deviceID)
+	} else {
+		q.queues[deviceID] = queue[1:]
+	}
+	return data, true
+}
+
+// Len returns the number of payloads queued for a device.
+func (q *DownlinkQueue) Len(deviceID string) int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return len(q.queues[deviceID])
+}
+
+type downlinkAnswer struct {
+	DownlinkData string `json:"downlinkData,omitempty"`
+	NoData       bool   `json:"noData,omitempty"`
+}
+
+type bidirResult struct {
+	data *DownlinkData
+	err  error
+}
+
+// serveBidir answers a DATA BIDIR callback. When the device requested a downlink,
+// the payload returned by the bidir function is sent, or else the next payload queued
+// for the device. The answer is always written before the downlink timeout expires,
+// falling back to the queue and then to a "no data" answer.
+func (h *Handler) serveBidir(w http.ResponseWriter, r *http.Request, b *Bidir) {
+	if !b.Ack {
+		if _, err := h.callBidir(r.Context(), b); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	timeout := h.downlinkTimeout
+	if timeout <= 0 {
+		timeout = DefaultDownlinkTimeout
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), timeout)
+	defer cancel()
+
+	done := make(chan bidirResult, 1)
+	go func() {
+		data, err := h.callBidir(ctx, b)
+		done <- bidirResult{data, err}
+	}()
+
+	var data *DownlinkData
+	select {
+	case res := <-done:
+		if res.err != nil && ctx.Err() == nil {
+			http.Error(w, res.err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if res.err == nil {
+			data = res.data
+		}
+	case <-ctx.Done():
+	}
+
+	if data == nil && h.downlinkQueue != nil {
+		if d, ok := h.downlinkQueue.Pop(b.Device); ok {
+			data = &d
+		}
+	}
+
+	answer := downlinkAnswer{NoData: true}
+	if data != nil {
+		answer = downlinkAnswer{DownlinkData: data.String()}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]downlinkAnswer{b.Device: answer})
+}
+
+func (h *Handler) callBidir(ctx context.Context, b *Bidir) (*DownlinkData, error) {
+	if h.bidir == nil {
+		return nil, nil
+	}
+	return h.bidir(ctx, b)
+}
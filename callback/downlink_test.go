@@ -0,0 +1,79 @@
+package callback
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseDownlinkData(t *testing.T) {
+	d, err := ParseDownlinkData("0102030405060708")
+	if err != nil {
+		t.Fatalf("ParseDownlinkData returned error: %v", err)
+	}
+	if got, want := d.String(), "0102030405060708"; got != want {
+		t.Errorf("String() is %q, want %q", got, want)
+	}
+
+	for _, s := range []string{"", "01020304", "010203040506070809", "zz02030405060708"} {
+		if _, err := ParseDownlinkData(s); err == nil {
+			t.Errorf("ParseDownlinkData(%q) succeeded, want error", s)
+		}
+	}
+}
+
+func serveBidir(h *Handler, ack bool) *httptest.ResponseRecorder {
+	body := `{"device":"1A2B","time":1600000000,"data":"01","seqNumber":1,"ack":false}`
+	if ack {
+		body = strings.Replace(body, `"ack":false`, `"ack":true`, 1)
+	}
+	req := httptest.NewRequest("POST", "/?type=bidir", strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestHandler_bidir(t *testing.T) {
+	data, _ := ParseDownlinkData("0102030405060708")
+	h := NewHandler()
+	h.HandleBidir(func(ctx context.Context, b *Bidir) (*DownlinkData, error) {
+		return &data, nil
+	})
+
+	rec := serveBidir(h, true)
+	if got, want := strings.TrimSpace(rec.Body.String()), `{"1A2B":{"downlinkData":"0102030405060708"}}`; got != want {
+		t.Errorf("ServeHTTP answered %s, want %s", got, want)
+	}
+
+	rec = serveBidir(h, false)
+	if rec.Code != http.StatusNoContent {
+		t.Errorf("ServeHTTP without ack answered %d, want %d", rec.Code, http.StatusNoContent)
+	}
+}
+
+func TestHandler_bidirQueueAndTimeout(t *testing.T) {
+	q := NewDownlinkQueue()
+	data, _ := ParseDownlinkData("AABBCCDDEEFF0011")
+	q.Push("1A2B", data)
+
+	h := NewHandler()
+	h.SetDownlinkQueue(q)
+	h.SetDownlinkTimeout(10 * time.Millisecond)
+	h.HandleBidir(func(ctx context.Context, b *Bidir) (*DownlinkData, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+
+	rec := serveBidir(h, true)
+	if got, want := strings.TrimSpace(rec.Body.String()), `{"1A2B":{"downlinkData":"AABBCCDDEEFF0011"}}`; got != want {
+		t.Errorf("ServeHTTP answered %s, want %s", got, want)
+	}
+
+	rec = serveBidir(h, true)
+	if got, want := strings.TrimSpace(rec.Body.String()), `{"1A2B":{"noData":true}}`; got != want {
+		t.Errorf("ServeHTTP with an empty queue answered %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,182 @@
+// Package callback receives the callbacks sent by the Sigfox backend.
+//
+// A Handler decodes the JSON bodies produced by the templates of this package,
+// such as UplinkBodyTemplate, and dispatches them to the functions registered
+// for their type. The type of a callback is read from the "type" query parameter
+// of its URL, e.g. https://example.com/sigfox?type=uplink.
+package callback
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// maxBodySize caps the size of the callback bodies read by Handler.
+const maxBodySize = 64 << 10
+
+// Handler is an http.Handler decoding Sigfox callbacks.
+type Handler struct {
+	uplink      func(context.Context, *Uplink) error
+	bidir       func(context.Context, *Bidir) (*DownlinkData, error)
+	status      func(context.Context, *Status) error
+	acknowledge func(context.Context, *Acknowledge) error
+	err         func(context.Context, *Error) error
+	geoloc      func(context.Context, *Geoloc) error
+
+	downlinkQueue   *DownlinkQueue
+	downlinkTimeout time.Duration
+}
+
+// NewHandler returns a handler without any registered function.
+func NewHandler() *Handler {
+	return &Handler{}
+}
+
+// HandleUplink registers the function called with DATA UPLINK callbacks.
+func (h *Handler) HandleUplink(fn func(ctx context.Context, u *Uplink) error) {
+	h.uplink = fn
+}
+
+// HandleBidir registers the function called with DATA BIDIR callbacks.
+// When the device requested a downlink, the function may return the payload to send;
+// returning nil falls back to the downlink queue. The context of the function is
+// canceled when the downlink timeout expires.
+func (h *Handler) HandleBidir(fn func(ctx context.Context, b *Bidir) (*DownlinkData, error)) {
+	h.bidir = fn
+}
+
+// SetDownlinkQueue sets the queue the downlink payloads are taken from
+// when the bidir function does not provide one.
+func (h *Handler) SetDownlinkQueue(q *DownlinkQueue) {
+	h.downlinkQueue = q
+}
+
+// SetDownlinkTimeout sets the time given to the bidir function to provide the downlink payload.
+// It must stay below the delay the Sigfox backend waits for the answer.
+func (h *Handler) SetDownlinkTimeout(d time.Duration) {
+	h.downlinkTimeout = d
+}
+
+// HandleStatus registers the function called with SERVICE STATUS callbacks.
+func (h *Handler) HandleStatus(fn func(ctx context.Context, s *Status) error) {
+	h.status = fn
+}
+
+// HandleAcknowledge registers the function called with SERVICE ACKNOWLEDGE callbacks.
+func (h *Handler) HandleAcknowledge(fn func(ctx context.Context, a *Acknowledge) error) {
+	h.acknowledge = fn
+}
+
+// HandleError registers the function called with ERROR callbacks.
+func (h *Handler) HandleError(fn func(ctx context.Context, e *Error) error) {
+	h.err = fn
+}
+
+// HandleGeoloc registers the function called with geolocation callbacks.
+func (h *Handler) HandleGeoloc(fn func(ctx context.Context, g *Geoloc) error) {
+	h.geoloc = fn
+}
+
+// ServeHTTP decodes the callback and calls the function registered for its type.
+// It answers 204 No Content when the function succeeds, 400 Bad Request when the
+// body cannot be decoded, 404 Not Found when no function is registered for the type
+// and 500 Internal Server Error when the function fails, so that the failure is
+// reported by the Sigfox backend. DATA BIDIR callbacks requesting a downlink are
+// answered with the downlink payload of the device.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" && r.Method != "PUT" {
+		w.Header().Set("Allow", "POST, PUT")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	typ := Type(r.URL.Query().Get("type"))
+	handled, known := h.handles(typ)
+	if !known {
+		http.Error(w, "unknown callback type: "+string(typ), http.StatusBadRequest)
+		return
+	}
+	if !handled {
+		http.Error(w, "no handler for callback type: "+string(typ), http.StatusNotFound)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxBodySize)
+	ctx := r.Context()
+
+	var err error
+	switch typ {
+	case TypeUplink:
+		var v Uplink
+		if !decode(w, r, &v) {
+			return
+		}
+		err = h.uplink(ctx, &v)
+	case TypeBidir:
+		var v Bidir
+		if !decode(w, r, &v) {
+			return
+		}
+		h.serveBidir(w, r, &v)
+		return
+	case TypeStatus:
+		var v Status
+		if !decode(w, r, &v) {
+			return
+		}
+		err = h.status(ctx, &v)
+	case TypeAcknowledge:
+		var v Acknowledge
+		if !decode(w, r, &v) {
+			return
+		}
+		err = h.acknowledge(ctx, &v)
+	case TypeError:
+		var v Error
+		if !decode(w, r, &v) {
+			return
+		}
+		err = h.err(ctx, &v)
+	case TypeGeoloc:
+		var v Geoloc
+		if !decode(w, r, &v) {
+			return
+		}
+		err = h.geoloc(ctx, &v)
+	}
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handles reports whether a function is registered for the type and whether the type is known.
+func (h *Handler) handles(typ Type) (handled, known bool) {
+	switch typ {
+	case TypeUplink:
+		return h.uplink != nil, true
+	case TypeBidir:
+		return h.bidir != nil || h.downlinkQueue != nil, true
+	case TypeStatus:
+		return h.status != nil, true
+	case TypeAcknowledge:
+		return h.acknowledge != nil, true
+	case TypeError:
+		return h.err != nil, true
+	case TypeGeoloc:
+		return h.geoloc != nil, true
+	}
+	return false, false
+}
+
+func decode(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		http.Error(w, "invalid callback body: "+err.Error(), http.StatusBadRequest)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,65 @@
+package callback
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHandler_uplink(t *testing.T) {
+	var got *Uplink
+	h := NewHandler()
+	h.HandleUplink(func(ctx context.Context, u *Uplink) error {
+		got = u
+		return nil
+	})
+
+	body := `{"device":"1A2B3C","deviceTypeId":"t1","time":1600000000,"data":"0102","seqNumber":42,` +
+		`"duplicate":false,"station":"0A1B","snr":12.5,"avgSnr":20.1,"rssi":-120.0,"lat":43.0,"lng":1.0}`
+	req := httptest.NewRequest("POST", "/sigfox?type=uplink", strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("ServeHTTP answered %d, want %d", rec.Code, http.StatusNoContent)
+	}
+	if got == nil || got.Device != "1A2B3C" || got.SeqNumber != 42 || got.Station != "0A1B" || got.Rssi != -120 {
+		t.Fatalf("uplink decoded as %+v", got)
+	}
+
+	m := got.Message()
+	if m.Time != 1600000000000 || m.Device.ID != "1A2B3C" || len(m.Rinfos) != 1 || m.Rinfos[0].BaseStation.ID != "0A1B" {
+		t.Errorf("Message() is %+v", m)
+	}
+}
+
+func TestHandler_errors(t *testing.T) {
+	h := NewHandler()
+	h.HandleStatus(func(ctx context.Context, s *Status) error {
+		return errors.New("failure")
+	})
+
+	tests := []struct {
+		method, target, body string
+		code                 int
+	}{
+		{"GET", "/?type=status", "", http.StatusMethodNotAllowed},
+		{"POST", "/?type=unknown", "{}", http.StatusBadRequest},
+		{"POST", "/?type=uplink", "{}", http.StatusNotFound},
+		{"POST", "/?type=status", "not json", http.StatusBadRequest},
+		{"POST", "/?type=status", "{}", http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+
+		if rec.Code != tt.code {
+			t.Errorf("%s %s answered %d, want %d", tt.method, tt.target, rec.Code, tt.code)
+		}
+	}
+}
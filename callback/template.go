@@ -0,0 +1,381 @@
+package callback
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/nightswinger/gofox/payload"
+	"github.com/nightswinger/gofox/sigfox"
+)
+
+// customDataPrefix starts the variables reading a field of the payload configuration.
+const customDataPrefix = "customData#"
+
+var (
+	radioVariables  = []string{"station", "snr", "avgSnr", "rssi", "lat", "lng"}
+	uplinkVariables = append([]string{
+		"device", "deviceTypeId", "time", "data", "seqNumber", "duplicate",
+	}, radioVariables...)
+)
+
+// Variables returns the variables allowed in the templates of a callback type and subtype.
+// The customData#name variables of the data callbacks are not listed.
+func Variables(callbackType sigfox.CallbackType, callbackSubtype sigfox.CallbackSubtype) ([]string, error) {
+	var vars []string
+	switch callbackType {
+	case sigfox.CallbackTypeData:
+		switch callbackSubtype {
+		case sigfox.CallbackSubtypeUplink:
+			vars = uplinkVariables
+		case sigfox.CallbackSubtypeBidir:
+			vars = append(append([]string(nil), uplinkVariables...), "ack")
+		case sigfox.CallbackSubtypeDataAdvanced:
+			vars = []string{"device", "deviceTypeId", "time", "data", "seqNumber", "lqi", "operatorName", "countryCode", "computedLocation"}
+		default:
+			return nil, fmt.Errorf("unknown data callback subtype %v", callbackSubtype)
+		}
+	case sigfox.CallbackTypeService:
+		switch callbackSubtype {
+		case sigfox.CallbackSubtypeStatus:
+			vars = append([]string{"device", "time", "seqNumber", "duplicate", "batt", "temp"}, radioVariables...)
+		case sigfox.CallbackSubtypeGeoloc:
+			vars = []string{"device", "time", "seqNumber", "lat", "lng", "radius", "source", "status"}
+		case sigfox.CallbackSubtypeAcknowledge:
+			vars = []string{"device", "time", "duplicate", "infoCode", "infoMessage", "downlinkAck", "downlinkOverusage"}
+		case sigfox.CallbackSubtypeRepeater:
+			vars = []string{"station", "time", "availablePower", "temperature", "voltage"}
+		default:
+			return nil, fmt.Errorf("unknown service callback subtype %v", callbackSubtype)
+		}
+	case sigfox.CallbackTypeError:
+		vars = []string{"device", "time", "info", "severity"}
+	default:
+		return nil, fmt.Errorf("unknown callback type %v", callbackType)
+	}
+
+	out := append([]string(nil), vars...)
+	sort.Strings(out)
+	return out, nil
+}
+
+// Template is a parsed callback template: a text holding {variable} references.
+// Braces which do not enclose a variable name, such as those of a JSON body, are kept as text.
+type Template struct {
+	parts []templatePart
+}
+
+type templatePart struct {
+	text string
+	// variable is the name of the variable when the part is not text.
+	variable string
+	offset   int
+}
+
+// ParseTemplate parses a template.
+func ParseTemplate(s string) (*Template, error) {
+	t := &Template{}
+	text := 0
+
+	for i := 0; i < len(s); i++ {
+		if s[i] != '{' {
+			continue
+		}
+
+		j := i + 1
+		for j < len(s) && isVariableByte(s[j]) {
+			j++
+		}
+		if j == i+1 {
+			continue
+		}
+		if j == len(s) || s[j] != '}' {
+			return nil, &TemplateError{Offset: i, Msg: fmt.Sprintf("unterminated variable %q", s[i:j])}
+		}
+
+		if text < i {
+			t.parts = append(t.parts, templatePart{text: s[text:i], offset: text})
+		}
+		t.parts = append(t.parts, templatePart{variable: s[i+1 : j], offset: i})
+		i = j
+		text = j + 1
+	}
+	if text < len(s) {
+		t.parts = append(t.parts, templatePart{text: s[text:], offset: text})
+	}
+
+	return t, nil
+}
+
+func isVariableByte(b byte) bool {
+	return b == '_' || b == '#' || b == '.' ||
+		'a' <= b && b <= 'z' || 'A' <= b && b <= 'Z' || '0' <= b && b <= '9'
+}
+
+// Variables returns the names of the variables used by the template, in order of appearance.
+func (t *Template) Variables() []string {
+	var vars []string
+	seen := make(map[string]bool)
+	for _, p := range t.parts {
+		if p.variable != "" && !seen[p.variable] {
+			seen[p.variable] = true
+			vars = append(vars, p.variable)
+		}
+	}
+	return vars
+}
+
+// Execute replaces the variables of the template with their values.
+// Variables missing from values are rendered empty.
+func (t *Template) Execute(values map[string]string) string {
+	var b strings.Builder
+	for _, p := range t.parts {
+		if p.variable == "" {
+			b.WriteString(p.text)
+		} else {
+			b.WriteString(values[p.variable])
+		}
+	}
+	return b.String()
+}
+
+// TemplateError reports an invalid template.
+type TemplateError struct {
+	// Field is the callback field holding the template, e.g. "bodyTemplate" or "headers.Authorization".
+	Field string
+	// Offset is the byte offset of the error in the template.
+	Offset int
+	Msg    string
+}
+
+func (e *TemplateError) Error() string {
+	if e.Field == "" {
+		return fmt.Sprintf("template: offset %d: %s", e.Offset, e.Msg)
+	}
+	return fmt.Sprintf("template %s: offset %d: %s", e.Field, e.Offset, e.Msg)
+}
+
+// TemplateErrors lists all the errors found in the templates of a callback.
+type TemplateErrors []*TemplateError
+
+func (e TemplateErrors) Error() string {
+	s := make([]string, len(e))
+	for i, err := range e {
+		s[i] = err.Error()
+	}
+	return strings.Join(s, "; ")
+}
+
+// callbackTemplates returns the templates of the callback by field name.
+func callbackTemplates(cb *sigfox.Callbacks) map[string]string {
+	templates := map[string]string{
+		"url":          cb.URL,
+		"bodyTemplate": cb.BodyTemplate,
+		"linePattern":  cb.LinePattern,
+		"subject":      cb.Subject,
+		"message":      cb.Message,
+	}
+	for k, v := range cb.Headers {
+		templates["headers."+k] = v
+	}
+	for k, v := range templates {
+		if v == "" {
+			delete(templates, k)
+		}
+	}
+	return templates
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Validate checks that the templates of a callback, its URL, headers, body, line pattern
+// and email subject and message, only use the variables allowed for its type and subtype.
+// The customData#name variables must name a field of the payload configuration of the callback.
+// All the errors found are returned as TemplateErrors.
+func Validate(cb *sigfox.Callbacks) error {
+	_, _, err := prepare(cb)
+	return err
+}
+
+// prepare validates the callback and returns its parsed templates and payload configuration.
+func prepare(cb *sigfox.Callbacks) (map[string]*Template, *payload.Config, error) {
+	vars, err := Variables(cb.CallbackType, cb.CallbackSubtype)
+	if err != nil {
+		return nil, nil, err
+	}
+	allowed := make(map[string]bool, len(vars))
+	for _, v := range vars {
+		allowed[v] = true
+	}
+
+	var errs TemplateErrors
+
+	var config *payload.Config
+	if cb.PayloadConfig != "" {
+		config, err = payload.Parse(cb.PayloadConfig)
+		if err != nil {
+			terr := &TemplateError{Field: "payloadConfig", Msg: err.Error()}
+			var serr *payload.SyntaxError
+			if errors.As(err, &serr) {
+				terr.Offset, terr.Msg = serr.Offset, serr.Msg
+			}
+			errs = append(errs, terr)
+		}
+	}
+	customData := make(map[string]bool)
+	if config != nil {
+		for _, f := range config.Fields {
+			customData[f.Name] = true
+		}
+	}
+
+	templates := callbackTemplates(cb)
+	parsed := make(map[string]*Template, len(templates))
+	for _, field := range sortedKeys(templates) {
+		t, err := ParseTemplate(templates[field])
+		if err != nil {
+			var terr *TemplateError
+			if !errors.As(err, &terr) {
+				terr = &TemplateError{Msg: err.Error()}
+			}
+			terr.Field = field
+			errs = append(errs, terr)
+			continue
+		}
+		parsed[field] = t
+
+		for _, p := range t.parts {
+			switch {
+			case p.variable == "" || allowed[p.variable]:
+			case strings.HasPrefix(p.variable, customDataPrefix) && cb.CallbackType == sigfox.CallbackTypeData:
+				name := strings.TrimPrefix(p.variable, customDataPrefix)
+				if cb.PayloadConfig == "" {
+					errs = append(errs, &TemplateError{Field: field, Offset: p.offset, Msg: fmt.Sprintf("variable %q requires a payload configuration", p.variable)})
+				} else if config != nil && !customData[name] {
+					errs = append(errs, &TemplateError{Field: field, Offset: p.offset, Msg: fmt.Sprintf("payload configuration has no field %q", name)})
+				}
+			default:
+				errs = append(errs, &TemplateError{Field: field, Offset: p.offset, Msg: fmt.Sprintf("variable %q is not allowed in this callback", p.variable)})
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, nil, errs
+	}
+	return parsed, config, nil
+}
+
+// Sample is a callback rendered from a message.
+type Sample struct {
+	URL         string
+	Headers     map[string]string
+	Body        string
+	LinePattern string
+	Subject     string
+	Message     string
+}
+
+// sampleValues holds the values of the variables a message does not provide.
+var sampleValues = map[string]string{
+	"deviceTypeId":      "5256c4d6c9a871b80f5a2e50",
+	"duplicate":         "false",
+	"ack":               "false",
+	"batt":              "3.3",
+	"temp":              "20.5",
+	"radius":            "1000",
+	"source":            "2",
+	"status":            "1",
+	"infoCode":          "0",
+	"infoMessage":       "Acked",
+	"downlinkAck":       "true",
+	"downlinkOverusage": "false",
+	"info":              "No message received since 2 days",
+	"severity":          "ERROR",
+	"operatorName":      "SIGFOX_France",
+	"countryCode":       "250",
+	"computedLocation":  "null",
+	"availablePower":    "14",
+	"temperature":       "20.5",
+	"voltage":           "3.3",
+}
+
+// messageValues returns the values of the variables of a message.
+// Like the Sigfox backend, times are given in seconds.
+func messageValues(m *sigfox.Message) map[string]string {
+	values := make(map[string]string, len(sampleValues)+10)
+	for k, v := range sampleValues {
+		values[k] = v
+	}
+
+	values["device"] = m.Device.ID
+	values["time"] = strconv.FormatInt(m.Time.Millis()/1000, 10)
+	values["data"] = m.Data
+	values["seqNumber"] = strconv.Itoa(int(m.SeqNumber))
+	values["lqi"] = strconv.Itoa(int(m.Lqi))
+	values["ack"] = strconv.FormatBool(m.AckRequired)
+
+	if len(m.Rinfos) > 0 {
+		r := m.Rinfos[0]
+		values["station"] = r.BaseStation.ID
+		values["rssi"] = formatFloat(r.Rssi)
+		values["snr"] = formatFloat(r.Snr)
+		values["lat"] = formatFloat(r.Lat)
+		values["lng"] = formatFloat(r.Lng)
+		avg, _ := m.AverageSnr()
+		values["avgSnr"] = formatFloat(avg)
+	}
+	return values
+}
+
+// Render validates the callback and renders its templates with the values of a message,
+// showing what the Sigfox backend would send for it. The customData#name variables
+// are decoded from the message data with the payload configuration of the callback.
+func Render(cb *sigfox.Callbacks, m *sigfox.Message) (*Sample, error) {
+	templates, config, err := prepare(cb)
+	if err != nil {
+		return nil, err
+	}
+
+	values := messageValues(m)
+	if config != nil {
+		decoded, err := config.DecodeHex(m.Data)
+		if err != nil {
+			return nil, err
+		}
+		for name, v := range decoded {
+			values[customDataPrefix+name] = fmt.Sprint(v)
+		}
+	}
+
+	execute := func(field string) string {
+		if t, ok := templates[field]; ok {
+			return t.Execute(values)
+		}
+		return ""
+	}
+
+	s := &Sample{
+		URL:         execute("url"),
+		Body:        execute("bodyTemplate"),
+		LinePattern: execute("linePattern"),
+		Subject:     execute("subject"),
+		Message:     execute("message"),
+	}
+	if len(cb.Headers) > 0 {
+		s.Headers = make(map[string]string, len(cb.Headers))
+		for k := range cb.Headers {
+			s.Headers[k] = execute("headers." + k)
+		}
+	}
+	return s, nil
+}
@@ -0,0 +1,114 @@
+package callback
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/nightswinger/gofox/sigfox"
+)
+
+func TestParseTemplate(t *testing.T) {
+	tmpl, err := ParseTemplate(`{"device":"{device}","temp":{customData#temp},"obj":{}}`)
+	if err != nil {
+		t.Fatalf("ParseTemplate returned error: %v", err)
+	}
+
+	if got, want := tmpl.Variables(), []string{"device", "customData#temp"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Variables() is %v, want %v", got, want)
+	}
+
+	got := tmpl.Execute(map[string]string{"device": "1A2B", "customData#temp": "21"})
+	if want := `{"device":"1A2B","temp":21,"obj":{}}`; got != want {
+		t.Errorf("Execute() is %s, want %s", got, want)
+	}
+
+	_, err = ParseTemplate(`https://example.com/{device`)
+	var terr *TemplateError
+	if !errors.As(err, &terr) || terr.Offset != 20 {
+		t.Errorf("ParseTemplate returned %v, want an unterminated variable at offset 20", err)
+	}
+}
+
+func TestValidate_bodyTemplates(t *testing.T) {
+	tests := []struct {
+		callbackType    sigfox.CallbackType
+		callbackSubtype sigfox.CallbackSubtype
+		body            string
+	}{
+		{sigfox.CallbackTypeData, sigfox.CallbackSubtypeUplink, UplinkBodyTemplate},
+		{sigfox.CallbackTypeData, sigfox.CallbackSubtypeBidir, BidirBodyTemplate},
+		{sigfox.CallbackTypeService, sigfox.CallbackSubtypeStatus, StatusBodyTemplate},
+		{sigfox.CallbackTypeService, sigfox.CallbackSubtypeAcknowledge, AcknowledgeBodyTemplate},
+		{sigfox.CallbackTypeService, sigfox.CallbackSubtypeGeoloc, GeolocBodyTemplate},
+		{sigfox.CallbackTypeError, 0, ErrorBodyTemplate},
+	}
+
+	for _, tt := range tests {
+		cb := &sigfox.Callbacks{CallbackType: tt.callbackType, CallbackSubtype: tt.callbackSubtype, BodyTemplate: tt.body}
+		if err := Validate(cb); err != nil {
+			t.Errorf("Validate(%v/%v) returned error: %v", tt.callbackType, tt.callbackSubtype, err)
+		}
+	}
+}
+
+func TestValidate_errors(t *testing.T) {
+	cb := &sigfox.Callbacks{
+		CallbackType:    sigfox.CallbackTypeData,
+		CallbackSubtype: sigfox.CallbackSubtypeUplink,
+		PayloadConfig:   "temp::int:16",
+		URL:             "https://example.com/{device}?batt={batt}",
+		Headers:         map[string]string{"X-Seq": "{seqNumber}", "X-Hum": "{customData#hum}"},
+		BodyTemplate:    `{"temp":{customData#temp}}`,
+	}
+
+	err := Validate(cb)
+	var errs TemplateErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("Validate returned %v, want TemplateErrors", err)
+	}
+
+	want := TemplateErrors{
+		{Field: "headers.X-Hum", Offset: 0, Msg: `payload configuration has no field "hum"`},
+		{Field: "url", Offset: 34, Msg: `variable "batt" is not allowed in this callback`},
+	}
+	if !reflect.DeepEqual(errs, want) {
+		t.Errorf("Validate returned %v, want %v", errs, want)
+	}
+
+	if _, err := Variables(sigfox.CallbackTypeService, 9); err == nil {
+		t.Error("Variables accepted an unknown subtype")
+	}
+}
+
+func TestRender(t *testing.T) {
+	cb := &sigfox.Callbacks{
+		CallbackType:    sigfox.CallbackTypeData,
+		CallbackSubtype: sigfox.CallbackSubtypeUplink,
+		PayloadConfig:   "temp::int:16 alert::bool:7",
+		URL:             "https://example.com/{device}",
+		Headers:         map[string]string{"X-Station": "{station}"},
+		BodyTemplate:    `{"time":{time},"seq":{seqNumber},"temp":{customData#temp},"alert":{customData#alert}}`,
+	}
+	m := &sigfox.Message{
+		Device:    sigfox.Device{ID: "1A2B"},
+		Time:      1600000000123,
+		Data:      "ff3880",
+		SeqNumber: 7,
+		Rinfos:    []sigfox.Rinfo{{BaseStation: sigfox.MinBaseStation{ID: "0A1B"}}},
+	}
+
+	s, err := Render(cb, m)
+	if err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+
+	want := &Sample{
+		URL:     "https://example.com/1A2B",
+		Headers: map[string]string{"X-Station": "0A1B"},
+		Body:    `{"time":1600000000,"seq":7,"temp":-200,"alert":true}`,
+	}
+	if !reflect.DeepEqual(s, want) {
+		t.Errorf("Render returned %+v, want %+v", s, want)
+	}
+}
@@ -0,0 +1,160 @@
+package callback
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/nightswinger/gofox/sigfox"
+)
+
+// Type identifies the kind of callback a request was sent by.
+type Type string
+
+const (
+	TypeUplink      Type = "uplink"
+	TypeBidir       Type = "bidir"
+	TypeStatus      Type = "status"
+	TypeAcknowledge Type = "acknowledge"
+	TypeError       Type = "error"
+	TypeGeoloc      Type = "geoloc"
+)
+
+// Body templates producing the JSON documents decoded by Handler.
+// They are meant to be set as the BodyTemplate of the callbacks,
+// with the "application/json" content type.
+const (
+	UplinkBodyTemplate = `{"device":"{device}","deviceTypeId":"{deviceTypeId}","time":{time},"data":"{data}","seqNumber":{seqNumber},` +
+		`"duplicate":{duplicate},"station":"{station}","snr":{snr},"avgSnr":{avgSnr},"rssi":{rssi},"lat":{lat},"lng":{lng}}`
+
+	BidirBodyTemplate = `{"device":"{device}","deviceTypeId":"{deviceTypeId}","time":{time},"data":"{data}","seqNumber":{seqNumber},` +
+		`"duplicate":{duplicate},"station":"{station}","snr":{snr},"avgSnr":{avgSnr},"rssi":{rssi},"lat":{lat},"lng":{lng},"ack":{ack}}`
+
+	StatusBodyTemplate = `{"device":"{device}","time":{time},"seqNumber":{seqNumber},"duplicate":{duplicate},` +
+		`"station":"{station}","snr":{snr},"avgSnr":{avgSnr},"rssi":{rssi},"lat":{lat},"lng":{lng},"batt":{batt},"temp":{temp}}`
+
+	AcknowledgeBodyTemplate = `{"device":"{device}","time":{time},"duplicate":{duplicate},"infoCode":{infoCode},` +
+		`"infoMessage":"{infoMessage}","downlinkAck":{downlinkAck},"downlinkOverusage":{downlinkOverusage}}`
+
+	ErrorBodyTemplate = `{"device":"{device}","time":{time},"info":"{info}","severity":"{severity}"}`
+
+	GeolocBodyTemplate = `{"device":"{device}","time":{time},"seqNumber":{seqNumber},` +
+		`"lat":{lat},"lng":{lng},"radius":{radius},"source":{source},"status":{status}}`
+)
+
+// Radio holds the radio information reported by the base station which received a frame.
+type Radio struct {
+	Station string  `json:"station,omitempty"`
+	Snr     float64 `json:"snr,omitempty"`
+	AvgSnr  float64 `json:"avgSnr,omitempty"`
+	Rssi    float64 `json:"rssi,omitempty"`
+	Lat     float64 `json:"lat,omitempty"`
+	Lng     float64 `json:"lng,omitempty"`
+}
+
+// Uplink is the body of a DATA UPLINK callback.
+type Uplink struct {
+	Device       string `json:"device"`
+	DeviceTypeID string `json:"deviceTypeId,omitempty"`
+	// Time is given in seconds since the Unix Epoch.
+	Time      int64  `json:"time"`
+	Data      string `json:"data"`
+	SeqNumber int32  `json:"seqNumber"`
+	Duplicate bool   `json:"duplicate"`
+	Radio
+}
+
+// Timestamp returns the time the frame was received at.
+func (u *Uplink) Timestamp() time.Time {
+	return time.Unix(u.Time, 0)
+}
+
+// Message converts the callback body into the message returned by the API.
+func (u *Uplink) Message() sigfox.Message {
+	m := sigfox.Message{
+		Device:    sigfox.Device{ID: u.Device},
+		Time:      sigfox.Timestamp(u.Time * 1000),
+		Data:      u.Data,
+		SeqNumber: u.SeqNumber,
+	}
+	if u.Station != "" {
+		m.Rinfos = []sigfox.Rinfo{{
+			BaseStation: sigfox.MinBaseStation{ID: u.Station},
+			Rssi:        u.Rssi,
+			Snr:         u.Snr,
+			Lat:         u.Lat,
+			Lng:         u.Lng,
+		}}
+	}
+	return m
+}
+
+// Bidir is the body of a DATA BIDIR callback.
+type Bidir struct {
+	Uplink
+	// Ack tells whether the device requested a downlink.
+	Ack bool `json:"ack"`
+}
+
+// Status is the body of a SERVICE STATUS callback.
+type Status struct {
+	Device string `json:"device"`
+	// Time is given in seconds since the Unix Epoch.
+	Time      int64 `json:"time"`
+	SeqNumber int32 `json:"seqNumber"`
+	Duplicate bool  `json:"duplicate"`
+	Radio
+	// Batt is the battery voltage in volts.
+	Batt float64 `json:"batt"`
+	// Temp is the temperature in degrees Celsius.
+	Temp float64 `json:"temp"`
+}
+
+// Acknowledge is the body of a SERVICE ACKNOWLEDGE callback.
+type Acknowledge struct {
+	Device string `json:"device"`
+	// Time is given in seconds since the Unix Epoch.
+	Time              int64  `json:"time"`
+	Duplicate         bool   `json:"duplicate"`
+	InfoCode          int32  `json:"infoCode"`
+	InfoMessage       string `json:"infoMessage"`
+	DownlinkAck       bool   `json:"downlinkAck"`
+	DownlinkOverusage bool   `json:"downlinkOverusage"`
+}
+
+// Error is the body of an ERROR callback.
+type Error struct {
+	Device string `json:"device"`
+	// Time is given in seconds since the Unix Epoch.
+	Time     int64  `json:"time"`
+	Info     string `json:"info"`
+	Severity string `json:"severity"`
+}
+
+// Geoloc is the body of a geolocation callback.
+type Geoloc struct {
+	Device string `json:"device"`
+	// Time is given in seconds since the Unix Epoch.
+	Time      int64   `json:"time"`
+	SeqNumber int32   `json:"seqNumber"`
+	Lat       float64 `json:"lat"`
+	Lng       float64 `json:"lng"`
+	// Radius is the accuracy of the location in meters.
+	Radius float64 `json:"radius"`
+	Source int32   `json:"source"`
+	Status int32   `json:"status"`
+}
+
+// Location converts the callback body into the location returned by the API.
+func (g *Geoloc) Location() sigfox.ComputedLocation {
+	return sigfox.ComputedLocation{
+		Lat:    g.Lat,
+		Lng:    g.Lng,
+		Radius: g.Radius,
+		Source: g.Source,
+		Status: g.Status,
+	}
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
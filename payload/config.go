@@ -0,0 +1,281 @@
+// Package payload parses the Sigfox custom payload configuration grammar
+// and decodes message payloads with it.
+//
+// A configuration is a list of fields separated by white spaces:
+//
+//	name:byteIndex:type:arguments
+//
+// The byte index may be left empty, in which case the field starts where the
+// previous one ended. The supported types are:
+//
+//	bool:bitIndex                 one bit of a byte, 7 being the most significant one
+//	char:length                   a string of length bytes
+//	int:bitLength[:endianness]    a signed integer of 1 to 32 bits
+//	uint:bitLength[:endianness]   an unsigned integer of 1 to 32 bits
+//	float:bitLength[:endianness]  a 32 or 64 bits IEEE 754 floating point number
+//
+// The endianness is either big-endian, the default, or little-endian.
+// Integers which are not a whole number of bytes are read bit by bit and
+// must be big-endian. Consecutive bool fields without byte index read the same byte.
+//
+// For example:
+//
+//	temp::int:16:little-endian humidity::uint:8 alert::bool:7 low::bool:6
+package payload
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Type is the type of a field.
+type Type string
+
+const (
+	Bool  Type = "bool"
+	Char  Type = "char"
+	Int   Type = "int"
+	Uint  Type = "uint"
+	Float Type = "float"
+)
+
+const (
+	bigEndian    = "big-endian"
+	littleEndian = "little-endian"
+)
+
+// Field is one field of a configuration.
+type Field struct {
+	Name string
+	// ByteIndex is the index of the first byte of the field, or -1 when it follows the previous field.
+	ByteIndex int
+	Type      Type
+	// BitIndex is the bit read by bool fields.
+	BitIndex int
+	// Length is the length in bytes of char fields and the length in bits of the other types.
+	Length       int
+	LittleEndian bool
+
+	// Offset is the position of the field in the parsed configuration.
+	Offset int
+}
+
+// String returns the field in the configuration grammar.
+func (f *Field) String() string {
+	idx := ""
+	if f.ByteIndex >= 0 {
+		idx = strconv.Itoa(f.ByteIndex)
+	}
+
+	s := fmt.Sprintf("%s:%s:%s:", f.Name, idx, f.Type)
+	switch f.Type {
+	case Bool:
+		s += strconv.Itoa(f.BitIndex)
+	default:
+		s += strconv.Itoa(f.Length)
+	}
+	if f.LittleEndian {
+		s += ":" + littleEndian
+	}
+	return s
+}
+
+// Config is a parsed payload configuration.
+type Config struct {
+	Fields []Field
+}
+
+// String returns the configuration in the grammar it was parsed from.
+func (c *Config) String() string {
+	s := make([]string, len(c.Fields))
+	for i := range c.Fields {
+		s[i] = c.Fields[i].String()
+	}
+	return strings.Join(s, " ")
+}
+
+// SyntaxError reports an invalid configuration.
+type SyntaxError struct {
+	// Offset is the byte offset of the error in the configuration.
+	Offset int
+	// Line and Column locate the error, both starting at 1.
+	Line, Column int
+	Msg          string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("payload config: %d:%d: %s", e.Line, e.Column, e.Msg)
+}
+
+type parser struct {
+	src string
+}
+
+func (p *parser) errorf(offset int, format string, args ...interface{}) error {
+	line, col := 1, 1
+	for _, r := range p.src[:offset] {
+		if r == '\n' {
+			line++
+			col = 1
+		} else {
+			col++
+		}
+	}
+	return &SyntaxError{Offset: offset, Line: line, Column: col, Msg: fmt.Sprintf(format, args...)}
+}
+
+// Parse parses a payload configuration.
+func Parse(s string) (*Config, error) {
+	p := &parser{src: s}
+	c := &Config{}
+	names := make(map[string]bool)
+
+	i := 0
+	for i < len(s) {
+		if isSpace(s[i]) {
+			i++
+			continue
+		}
+
+		start := i
+		for i < len(s) && !isSpace(s[i]) {
+			i++
+		}
+
+		f, err := p.parseField(s[start:i], start)
+		if err != nil {
+			return nil, err
+		}
+		if names[f.Name] {
+			return nil, p.errorf(start, "duplicate field name %q", f.Name)
+		}
+		names[f.Name] = true
+		c.Fields = append(c.Fields, f)
+	}
+
+	if len(c.Fields) == 0 {
+		return nil, p.errorf(len(s), "no field")
+	}
+	return c, nil
+}
+
+// MustParse is like Parse but panics if the configuration is invalid.
+func MustParse(s string) *Config {
+	c, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+func (p *parser) parseField(tok string, offset int) (Field, error) {
+	f := Field{ByteIndex: -1, Offset: offset}
+
+	parts := strings.Split(tok, ":")
+	// pos returns the offset of the i-th part of the field.
+	pos := func(i int) int {
+		o := offset
+		for _, part := range parts[:i] {
+			o += len(part) + 1
+		}
+		return o
+	}
+
+	if len(parts) < 4 {
+		return f, p.errorf(offset, "field %q must be name:byteIndex:type:arguments", tok)
+	}
+
+	f.Name = parts[0]
+	if !validName(f.Name) {
+		return f, p.errorf(offset, "invalid field name %q", f.Name)
+	}
+
+	if parts[1] != "" {
+		idx, err := strconv.Atoi(parts[1])
+		// Sigfox payloads are at most 12 bytes long.
+		if err != nil || idx < 0 || idx > 11 {
+			return f, p.errorf(pos(1), "invalid byte index %q, must be between 0 and 11", parts[1])
+		}
+		f.ByteIndex = idx
+	}
+
+	f.Type = Type(parts[2])
+	args := parts[3:]
+
+	switch f.Type {
+	case Bool:
+		if len(args) != 1 {
+			return f, p.errorf(pos(3), "bool takes a bit index")
+		}
+		bit, err := strconv.Atoi(args[0])
+		if err != nil || bit < 0 || bit > 7 {
+			return f, p.errorf(pos(3), "invalid bit index %q, must be between 0 and 7", args[0])
+		}
+		f.BitIndex = bit
+		return f, nil
+	case Char:
+		if len(args) != 1 {
+			return f, p.errorf(pos(3), "char takes a length")
+		}
+		n, err := strconv.Atoi(args[0])
+		if err != nil || n < 1 || n > 12 {
+			return f, p.errorf(pos(3), "invalid char length %q, must be between 1 and 12", args[0])
+		}
+		f.Length = n
+		return f, nil
+	case Int, Uint, Float:
+	default:
+		return f, p.errorf(pos(2), "unknown type %q", parts[2])
+	}
+
+	if len(args) > 2 {
+		return f, p.errorf(pos(5), "unexpected argument %q", args[2])
+	}
+
+	n, err := strconv.Atoi(args[0])
+	if err != nil {
+		return f, p.errorf(pos(3), "invalid length %q", args[0])
+	}
+	f.Length = n
+
+	if f.Type == Float {
+		if n != 32 && n != 64 {
+			return f, p.errorf(pos(3), "invalid float length %d, must be 32 or 64", n)
+		}
+	} else if n < 1 || n > 32 {
+		return f, p.errorf(pos(3), "invalid %s length %d, must be between 1 and 32", f.Type, n)
+	}
+
+	if len(args) == 2 {
+		switch args[1] {
+		case bigEndian:
+		case littleEndian:
+			if n%8 != 0 {
+				return f, p.errorf(pos(4), "little-endian requires a whole number of bytes, got %d bits", n)
+			}
+			f.LittleEndian = true
+		default:
+			return f, p.errorf(pos(4), "invalid endianness %q", args[1])
+		}
+	}
+
+	return f, nil
+}
+
+func validName(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r != '_' && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return true
+}
+
+func isSpace(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\n' || b == '\r'
+}
@@ -0,0 +1,115 @@
+package payload
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math"
+)
+
+// DecodeHex decodes the hexadecimal payload of a message, such as Message.Data.
+func (c *Config) DecodeHex(s string) (map[string]interface{}, error) {
+	data, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid payload %q: %v", s, err)
+	}
+	return c.Decode(data), nil
+}
+
+// Decode decodes a payload into a map of the field names to their values,
+// which are bool, string, int64, uint64 or float64 according to the field types.
+// As done by the Sigfox backend, the fields which do not fit in the payload are left out.
+func (c *Config) Decode(data []byte) map[string]interface{} {
+	out := make(map[string]interface{}, len(c.Fields))
+
+	// pos is the position of the next bit to read, counted from the most
+	// significant bit of the first byte.
+	pos := 0
+	boolByte := -1
+
+	for i := range c.Fields {
+		f := &c.Fields[i]
+
+		if f.Type == Bool {
+			b := f.ByteIndex
+			if b < 0 {
+				b = boolByte
+				if b < 0 {
+					b = (pos + 7) / 8
+				}
+			}
+			boolByte = b
+			pos = (b + 1) * 8
+
+			if b >= 0 && b < len(data) {
+				out[f.Name] = data[b]&(1<<uint(f.BitIndex)) != 0
+			}
+			continue
+		}
+		boolByte = -1
+
+		if f.ByteIndex >= 0 {
+			pos = f.ByteIndex * 8
+		}
+
+		var bits int
+		switch f.Type {
+		case Char:
+			pos = (pos + 7) / 8 * 8
+			bits = f.Length * 8
+		case Float:
+			pos = (pos + 7) / 8 * 8
+			bits = f.Length
+		default:
+			if f.LittleEndian {
+				pos = (pos + 7) / 8 * 8
+			}
+			bits = f.Length
+		}
+
+		start := pos
+		pos += bits
+		if start < 0 || pos < start || pos > len(data)*8 {
+			continue
+		}
+
+		switch f.Type {
+		case Char:
+			out[f.Name] = string(data[start/8 : pos/8])
+		case Float:
+			v := readUint(data, start, bits, f.LittleEndian)
+			if bits == 32 {
+				out[f.Name] = float64(math.Float32frombits(uint32(v)))
+			} else {
+				out[f.Name] = math.Float64frombits(v)
+			}
+		case Uint:
+			out[f.Name] = readUint(data, start, bits, f.LittleEndian)
+		case Int:
+			v := readUint(data, start, bits, f.LittleEndian)
+			if v&(1<<uint(bits-1)) != 0 {
+				out[f.Name] = int64(v) - int64(1)<<uint(bits)
+			} else {
+				out[f.Name] = int64(v)
+			}
+		}
+	}
+
+	return out
+}
+
+// readUint reads n bits starting at bit pos. Little-endian values are byte aligned.
+func readUint(data []byte, pos, n int, littleEndian bool) uint64 {
+	var v uint64
+	if littleEndian {
+		for i := n/8 - 1; i >= 0; i-- {
+			v = v<<8 | uint64(data[pos/8+i])
+		}
+		return v
+	}
+
+	for i := 0; i < n; i++ {
+		bit := pos + i
+		v = v<<1 | uint64(data[bit/8]>>(7-uint(bit%8))&1)
+	}
+	return v
+}
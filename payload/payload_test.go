@@ -0,0 +1,153 @@
+package payload
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	c, err := Parse("temp::int:16:little-endian\n humidity::uint:8 alert:3:bool:7")
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+
+	want := []Field{
+		{Name: "temp", ByteIndex: -1, Type: Int, Length: 16, LittleEndian: true, Offset: 0},
+		{Name: "humidity", ByteIndex: -1, Type: Uint, Length: 8, Offset: 28},
+		{Name: "alert", ByteIndex: 3, Type: Bool, BitIndex: 7, Offset: 45},
+	}
+	if !reflect.DeepEqual(c.Fields, want) {
+		t.Errorf("Parse returned %+v, want %+v", c.Fields, want)
+	}
+	if got, want := c.String(), "temp::int:16:little-endian humidity::uint:8 alert:3:bool:7"; got != want {
+		t.Errorf("String() is %q, want %q", got, want)
+	}
+}
+
+func TestParse_errors(t *testing.T) {
+	tests := []struct {
+		config       string
+		line, column int
+	}{
+		{"", 1, 1},
+		{"temp::int", 1, 1},
+		{"a::uint:8 b::unknown:8", 1, 14},
+		{"a::uint:8\nb::uint:40", 2, 9},
+		{"a::uint:12:little-endian", 1, 12},
+		{"a::float:16", 1, 10},
+		{"a::bool:8", 1, 9},
+		{"a:x:uint:8", 1, 3},
+		{"a::uint:8 a::uint:8", 1, 11},
+		{"a-b::uint:8", 1, 1},
+		{"a::uint:8:big-endian:x", 1, 22},
+		{"a:12:uint:8", 1, 3},
+		{"a:1152921504606846976:uint:8", 1, 3},
+	}
+
+	for _, tt := range tests {
+		_, err := Parse(tt.config)
+		var serr *SyntaxError
+		if !errors.As(err, &serr) {
+			t.Errorf("Parse(%q) returned %v, want a *SyntaxError", tt.config, err)
+			continue
+		}
+		if serr.Line != tt.line || serr.Column != tt.column {
+			t.Errorf("Parse(%q) error at %d:%d, want %d:%d (%v)", tt.config, serr.Line, serr.Column, tt.line, tt.column, err)
+		}
+	}
+}
+
+func TestDecodeHex(t *testing.T) {
+	tests := []struct {
+		config, data string
+		want         map[string]interface{}
+	}{
+		{
+			"temp::int:16:little-endian humidity::uint:8",
+			"38ff41",
+			map[string]interface{}{"temp": int64(-200), "humidity": uint64(65)},
+		},
+		{
+			"hi::uint:4 lo::uint:4 s::char:3 a::bool:7 b::bool:0",
+			"a5414243" + "81",
+			map[string]interface{}{"hi": uint64(10), "lo": uint64(5), "s": "ABC", "a": true, "b": true},
+		},
+		{
+			"f::float:32 d:4:float:32:little-endian",
+			"3fc00000" + "0000c03f",
+			map[string]interface{}{"f": 1.5, "d": 1.5},
+		},
+		{
+			"a::uint:8 b::uint:16",
+			"01",
+			map[string]interface{}{"a": uint64(1)},
+		},
+	}
+
+	for _, tt := range tests {
+		got, err := MustParse(tt.config).DecodeHex(tt.data)
+		if err != nil {
+			t.Errorf("DecodeHex(%q) returned error: %v", tt.data, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%q: DecodeHex(%q) = %v, want %v", tt.config, tt.data, got, tt.want)
+		}
+	}
+}
+
+func TestDecode_outOfRange(t *testing.T) {
+	c := &Config{Fields: []Field{
+		{Name: "a", ByteIndex: 1 << 60, Type: Uint, Length: 8},
+		{Name: "b", ByteIndex: 1 << 60, Type: Bool},
+		{Name: "c", ByteIndex: -1, Type: Uint, Length: 8},
+	}}
+
+	if got := c.Decode([]byte{1, 2}); len(got) != 0 {
+		t.Errorf("Decode returned %v, want no values", got)
+	}
+}
+
+func FuzzParse(f *testing.F) {
+	f.Add("temp::int:16:little-endian humidity::uint:8")
+	f.Add("a:0:bool:7 b:0:bool:6 s:1:char:4")
+	f.Add("f::float:64:big-endian")
+
+	f.Fuzz(func(t *testing.T, s string) {
+		c, err := Parse(s)
+		if err != nil {
+			var serr *SyntaxError
+			if !errors.As(err, &serr) || serr.Offset < 0 || serr.Offset > len(s) {
+				t.Fatalf("Parse(%q) returned an invalid error: %v", s, err)
+			}
+			return
+		}
+
+		again, err := Parse(c.String())
+		if err != nil {
+			t.Fatalf("Parse(%q) of the formatted config returned error: %v", c.String(), err)
+		}
+		if again.String() != c.String() {
+			t.Fatalf("formatting is not stable: %q != %q", again.String(), c.String())
+		}
+	})
+}
+
+func FuzzDecode(f *testing.F) {
+	f.Add("temp::int:16:little-endian humidity::uint:8", []byte{0x38, 0xff, 0x41})
+	f.Add("hi::uint:4 lo::uint:4 s::char:3 a::bool:7", []byte{0xa5})
+	f.Add("x:11:uint:32", []byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12})
+	f.Add("a:1152921504606846976:uint:8", []byte{1, 2})
+
+	f.Fuzz(func(t *testing.T, config string, data []byte) {
+		c, err := Parse(config)
+		if err != nil {
+			return
+		}
+		out := c.Decode(data)
+		if len(out) > len(c.Fields) {
+			t.Fatalf("Decode returned %d values for %d fields", len(out), len(c.Fields))
+		}
+	})
+}
@@ -3,7 +3,6 @@ package sigfox
 import (
 	"context"
 	"fmt"
-	"net/http"
 )
 
 type ApiUserService service
@@ -12,17 +11,17 @@ type ApiUser struct {
 	Name         string       `json:"name,omitempty"`
 	Timezone     string       `json:"timezone,omitempty"`
 	Group        MinimalGroup `json:"group,omitempty"`
-	CreationTime int64        `json:"creationTime,omitempty"`
+	CreationTime Timestamp    `json:"creationTime,omitempty"`
 	ID           string       `json:"id,omitempty"`
 	AccessToken  string       `json:"accessToken,omitempty"`
 	Profiles     []Profile    `json:"profiles,omitempty"`
 }
 
 type MinimalGroup struct {
-	Name  string `json:"name,omitempty"`
-	Type  int32  `json:"type,omitempty"`
-	ID    string `json:"id,omitempty"`
-	Level int32  `json:"level,omitempty"`
+	Name  string    `json:"name,omitempty"`
+	Type  GroupType `json:"type,omitempty"`
+	ID    string    `json:"id,omitempty"`
+	Level int32     `json:"level,omitempty"`
 }
 
 type ListApiUsersOutput struct {
@@ -30,68 +29,53 @@ type ListApiUsersOutput struct {
 	Paging Pagination `json:"paging"`
 }
 
-func (s *ApiUserService) List(params ...QueryParam) (*ListApiUsersOutput, *http.Response, error) {
-	return s.ListContext(context.Background(), params...)
+// ListWithResponse retrieve a list of API users according to visibility permissions and request filters.
+func (s *ApiUserService) ListWithResponse(params ...QueryParam) (*ListApiUsersOutput, *Response, error) {
+	return s.ListWithResponseContext(context.Background(), params...)
 }
 
-func (s *ApiUserService) ListContext(ctx context.Context, params ...QueryParam) (*ListApiUsersOutput, *http.Response, error) {
+// ListWithResponseContext retrieve a list of API users according to visibility permissions and request filters with context.
+func (s *ApiUserService) ListWithResponseContext(ctx context.Context, params ...QueryParam) (*ListApiUsersOutput, *Response, error) {
 	opt := &QueryParams{}
 	for _, param := range params {
 		param(opt)
 	}
 
-	spath := fmt.Sprintf("api-users/")
-	spath, err := addOptions(spath, opt)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.newRequest(ctx, "GET", spath, nil)
+	spath, err := addOptions("/api-users", opt)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	res, err := s.client.Do(ctx, req)
-	if err != nil {
-		return nil, res, err
-	}
-
 	var out ListApiUsersOutput
-	if err := decodeBody(res, &out); err != nil {
+	res, err := s.client.call(ctx, "GET", spath, nil, &out)
+	if err != nil {
 		return nil, res, err
 	}
 
 	return &out, res, nil
 }
 
-func (s *ApiUserService) Info(ApiUserID string, params ...QueryParam) (*ApiUser, *http.Response, error) {
-	return s.InfoContext(context.Background(), ApiUserID, params...)
+// InfoWithResponse retrieve information about an API user.
+func (s *ApiUserService) InfoWithResponse(ApiUserID string, params ...QueryParam) (*ApiUser, *Response, error) {
+	return s.InfoWithResponseContext(context.Background(), ApiUserID, params...)
 }
 
-func (s *ApiUserService) InfoContext(ctx context.Context, ApiUserID string, params ...QueryParam) (*ApiUser, *http.Response, error) {
+// InfoWithResponseContext retrieve information about an API user with context.
+func (s *ApiUserService) InfoWithResponseContext(ctx context.Context, ApiUserID string, params ...QueryParam) (*ApiUser, *Response, error) {
 	opt := &QueryParams{}
 	for _, param := range params {
 		param(opt)
 	}
 
-	spath := fmt.Sprintf("api-users/%s", ApiUserID)
+	spath := fmt.Sprintf("/api-users/%s", ApiUserID)
 	spath, err := addOptions(spath, opt)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	req, err := s.client.newRequest(ctx, "GET", spath, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	res, err := s.client.Do(ctx, req)
-	if err != nil {
-		return nil, res, err
-	}
-
 	var out ApiUser
-	if err := decodeBody(res, &out); err != nil {
+	res, err := s.client.call(ctx, "GET", spath, nil, &out)
+	if err != nil {
 		return nil, res, err
 	}
 
@@ -0,0 +1,375 @@
+package sigfox
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+)
+
+// CallbackConfig is the desired set of callbacks of device types, keyed by device type ID.
+// The callbacks of the device types which are not listed are left untouched.
+//
+// The configuration is read from YAML or JSON, using the field names of the API:
+//
+//	{
+//	  "deviceTypes": {
+//	    "5256c4d6c9a871b80f5a2e50": [
+//	      {"channel": "URL", "callbackType": "DATA", "callbackSubtype": "UPLINK", "enabled": true,
+//	       "url": "https://example.com/sigfox", "httpMethod": "POST", "contentType": "application/json",
+//	       "bodyTemplate": "{\"device\":\"{device}\"}"}
+//	    ]
+//	  }
+//	}
+//
+// or, in YAML:
+//
+//	deviceTypes:
+//	  5256c4d6c9a871b80f5a2e50:
+//	    - channel: URL
+//	      callbackType: DATA
+//	      callbackSubtype: UPLINK
+//	      enabled: true
+//	      url: https://example.com/sigfox
+//	      httpMethod: POST
+//	      contentType: application/json
+//	      bodyTemplate: '{"device":"{device}"}'
+type CallbackConfig struct {
+	DeviceTypes map[string][]CreateCallbackInput `json:"deviceTypes"`
+
+	// ValidateCallback, when set, is called with every desired callback after the checks
+	// of CreateCallbackInput.Validate, e.g. callback.Validate to check the templates.
+	ValidateCallback func(cb *Callbacks) error `json:"-"`
+}
+
+// validate checks every desired callback, so that a plan is never made of invalid callbacks.
+func (config *CallbackConfig) validate(deviceTypeIDs []string) error {
+	for _, id := range deviceTypeIDs {
+		for i := range config.DeviceTypes[id] {
+			input := &config.DeviceTypes[id][i]
+			err := input.Validate()
+			if err == nil && config.ValidateCallback != nil {
+				err = config.ValidateCallback(&input.Callbacks)
+			}
+			if err != nil {
+				return errors.Wrapf(err, "callback %d of device type %s", i, id)
+			}
+		}
+	}
+	return nil
+}
+
+// LoadCallbackConfig reads a callback configuration from YAML or JSON.
+func LoadCallbackConfig(r io.Reader) (*CallbackConfig, error) {
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	// JSON being valid YAML, both are decoded through JSON.
+	b, err = yaml.YAMLToJSON(b)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid callback configuration")
+	}
+
+	var config CallbackConfig
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&config); err != nil {
+		return nil, errors.Wrap(err, "invalid callback configuration")
+	}
+	return &config, nil
+}
+
+// CallbackAction is the action planned for a callback.
+type CallbackAction string
+
+const (
+	CallbackCreate CallbackAction = "create"
+	CallbackUpdate CallbackAction = "update"
+	CallbackDelete CallbackAction = "delete"
+)
+
+// CallbackChange is a change planned for a callback of a device type.
+type CallbackChange struct {
+	Action       CallbackAction
+	DeviceTypeID string
+	// Current is the callback as returned by the API, nil for creations.
+	Current *Callbacks
+	// Desired is the callback from the configuration, nil for deletions.
+	Desired *CreateCallbackInput
+	// Fields are the JSON names of the fields changed by an update.
+	Fields []string
+}
+
+// CallbackPlan is the list of changes bringing the callbacks in line with a configuration.
+type CallbackPlan struct {
+	Changes []CallbackChange
+}
+
+// Empty reports whether the callbacks already match the configuration.
+func (p *CallbackPlan) Empty() bool {
+	return len(p.Changes) == 0
+}
+
+// String describes the changes of the plan, one per line.
+func (p *CallbackPlan) String() string {
+	if p.Empty() {
+		return "No changes.\n"
+	}
+
+	var b strings.Builder
+	counts := make(map[CallbackAction]int)
+	for _, c := range p.Changes {
+		counts[c.Action]++
+
+		var cb *Callbacks
+		switch c.Action {
+		case CallbackCreate:
+			b.WriteString("+ ")
+			cb = &c.Desired.Callbacks
+		case CallbackUpdate:
+			b.WriteString("~ ")
+			cb = c.Current
+		case CallbackDelete:
+			b.WriteString("- ")
+			cb = c.Current
+		}
+		fmt.Fprintf(&b, "device type %s: %s", c.DeviceTypeID, describeCallback(cb))
+		if cb.ID != "" {
+			fmt.Fprintf(&b, " (%s)", cb.ID)
+		}
+		if len(c.Fields) > 0 {
+			fmt.Fprintf(&b, ": %s", strings.Join(c.Fields, ", "))
+		}
+		if c.Current != nil && c.Current.Dead {
+			b.WriteString(" [dead]")
+		}
+		b.WriteString("\n")
+	}
+	fmt.Fprintf(&b, "Plan: %d to create, %d to update, %d to delete.\n",
+		counts[CallbackCreate], counts[CallbackUpdate], counts[CallbackDelete])
+	return b.String()
+}
+
+func describeCallback(cb *Callbacks) string {
+	// Error callbacks have no subtype.
+	s := fmt.Sprintf("%s callback %v", cb.Channel, cb.CallbackType)
+	if cb.CallbackType != CallbackTypeError {
+		s += fmt.Sprintf("/%v", cb.CallbackSubtype)
+	}
+	if target := callbackTarget(cb); target != "" {
+		s += " " + target
+	}
+	return s
+}
+
+// callbackTarget returns where the callback sends its messages.
+func callbackTarget(cb *Callbacks) string {
+	if cb.URL != "" {
+		return cb.URL
+	}
+	return cb.Recipient
+}
+
+// callbackKey identifies the callbacks of a device type which are not given an ID
+// in the configuration. The channel, type and subtype of a callback cannot be updated.
+type callbackKey struct {
+	channel         string
+	callbackType    CallbackType
+	callbackSubtype CallbackSubtype
+	target          string
+}
+
+func keyOf(cb *Callbacks) callbackKey {
+	return callbackKey{cb.Channel, cb.CallbackType, cb.CallbackSubtype, callbackTarget(cb)}
+}
+
+// callbackFields are the fields compared by the plan. The ID and the dead flag are
+// managed by the server, and the content type is not returned by the API.
+var callbackFields = []struct {
+	name string
+	get  func(*Callbacks) interface{}
+}{
+	{"enabled", func(cb *Callbacks) interface{} { return cb.Enabled }},
+	{"sendDuplicate", func(cb *Callbacks) interface{} { return cb.SendDuplicate }},
+	{"payloadConfig", func(cb *Callbacks) interface{} { return cb.PayloadConfig }},
+	{"url", func(cb *Callbacks) interface{} { return cb.URL }},
+	{"httpMethod", func(cb *Callbacks) interface{} { return cb.HTTPMethod }},
+	{"downlinkHook", func(cb *Callbacks) interface{} { return cb.DownlinkHook }},
+	{"headers", func(cb *Callbacks) interface{} {
+		if len(cb.Headers) == 0 {
+			return map[string]string(nil)
+		}
+		return cb.Headers
+	}},
+	{"sendSni", func(cb *Callbacks) interface{} { return cb.SendSni }},
+	{"bodyTemplate", func(cb *Callbacks) interface{} { return cb.BodyTemplate }},
+	{"linePattern", func(cb *Callbacks) interface{} { return cb.LinePattern }},
+	{"subject", func(cb *Callbacks) interface{} { return cb.Subject }},
+	{"recipient", func(cb *Callbacks) interface{} { return cb.Recipient }},
+	{"message", func(cb *Callbacks) interface{} { return cb.Message }},
+}
+
+func diffCallbacks(current, desired *Callbacks) []string {
+	var fields []string
+	for _, f := range callbackFields {
+		if !reflect.DeepEqual(f.get(current), f.get(desired)) {
+			fields = append(fields, f.name)
+		}
+	}
+	return fields
+}
+
+// PlanCallbacks compare the callbacks of the device types of a configuration with the configuration.
+func (s *DeviceTypeService) PlanCallbacks(config *CallbackConfig) (*CallbackPlan, error) {
+	return s.PlanCallbacksContext(context.Background(), config)
+}
+
+// PlanCallbacksContext compare the callbacks of the device types of a configuration with the configuration with context.
+// The desired callbacks given an ID are matched with the callback of that ID, the others with the callback
+// of the same channel, type, subtype and URL or recipient. Callbacks whose channel, type or subtype change
+// are deleted and created again, and the callbacks missing from the configuration are deleted.
+// The desired callbacks are validated first, no plan being returned if one of them is invalid.
+func (s *DeviceTypeService) PlanCallbacksContext(ctx context.Context, config *CallbackConfig) (*CallbackPlan, error) {
+	ids := make([]string, 0, len(config.DeviceTypes))
+	for id := range config.DeviceTypes {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	if err := config.validate(ids); err != nil {
+		return nil, err
+	}
+
+	plan := &CallbackPlan{}
+	for _, id := range ids {
+		list, _, err := s.ListCallbacksWithResponseContext(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		changes, err := planDeviceType(id, list.Data, config.DeviceTypes[id])
+		if err != nil {
+			return nil, err
+		}
+		plan.Changes = append(plan.Changes, changes...)
+	}
+	return plan, nil
+}
+
+func planDeviceType(deviceTypeID string, current []Callbacks, desired []CreateCallbackInput) ([]CallbackChange, error) {
+	byID := make(map[string]*Callbacks, len(current))
+	byKey := make(map[callbackKey][]*Callbacks)
+	for i := range current {
+		cb := &current[i]
+		byID[cb.ID] = cb
+		byKey[keyOf(cb)] = append(byKey[keyOf(cb)], cb)
+	}
+
+	matched := make(map[string]bool)
+	var deletes, updates, creates []CallbackChange
+
+	// Desired callbacks with an ID are matched first, so that the others cannot take their callback.
+	match := make([]*Callbacks, len(desired))
+	for i := range desired {
+		d := &desired[i]
+		if d.ID == "" {
+			continue
+		}
+		cb, ok := byID[d.ID]
+		if !ok {
+			return nil, fmt.Errorf("device type %s has no callback %s", deviceTypeID, d.ID)
+		}
+		if matched[cb.ID] {
+			return nil, fmt.Errorf("callback %s of device type %s is configured twice", d.ID, deviceTypeID)
+		}
+		matched[cb.ID] = true
+		match[i] = cb
+	}
+	for i := range desired {
+		d := &desired[i]
+		if d.ID != "" {
+			continue
+		}
+		for _, cb := range byKey[keyOf(&d.Callbacks)] {
+			if !matched[cb.ID] {
+				matched[cb.ID] = true
+				match[i] = cb
+				break
+			}
+		}
+	}
+
+	for i := range desired {
+		d := &desired[i]
+		cb := match[i]
+		switch {
+		case cb == nil:
+			creates = append(creates, CallbackChange{Action: CallbackCreate, DeviceTypeID: deviceTypeID, Desired: d})
+		case cb.Channel != d.Channel || cb.CallbackType != d.CallbackType || cb.CallbackSubtype != d.CallbackSubtype:
+			deletes = append(deletes, CallbackChange{Action: CallbackDelete, DeviceTypeID: deviceTypeID, Current: cb})
+			created := *d
+			created.ID = ""
+			creates = append(creates, CallbackChange{Action: CallbackCreate, DeviceTypeID: deviceTypeID, Desired: &created})
+		default:
+			if fields := diffCallbacks(cb, &d.Callbacks); len(fields) > 0 {
+				updates = append(updates, CallbackChange{Action: CallbackUpdate, DeviceTypeID: deviceTypeID, Current: cb, Desired: d, Fields: fields})
+			}
+		}
+	}
+
+	for i := range current {
+		if cb := &current[i]; !matched[cb.ID] {
+			deletes = append(deletes, CallbackChange{Action: CallbackDelete, DeviceTypeID: deviceTypeID, Current: cb})
+		}
+	}
+
+	changes := append(deletes, updates...)
+	return append(changes, creates...), nil
+}
+
+// ApplyCallbacks apply the changes of a plan.
+func (s *DeviceTypeService) ApplyCallbacks(plan *CallbackPlan) error {
+	return s.ApplyCallbacksContext(context.Background(), plan)
+}
+
+// ApplyCallbacksContext apply the changes of a plan with context.
+// The changes are applied in order, deletions first, and the first failure stops the apply.
+// Planning again after a failure resumes from where it stopped.
+func (s *DeviceTypeService) ApplyCallbacksContext(ctx context.Context, plan *CallbackPlan) error {
+	for _, c := range plan.Changes {
+		var err error
+		switch c.Action {
+		case CallbackCreate:
+			input := *c.Desired
+			input.ID = ""
+			input.Dead = false
+			_, _, err = s.CreateCallbackWithResponseContext(ctx, c.DeviceTypeID, &input)
+		case CallbackUpdate:
+			input := &UpdateCallbackInput{Callbacks: c.Desired.Callbacks, ContentType: c.Desired.ContentType}
+			input.ID = ""
+			input.Dead = false
+			_, err = s.UpdateCallbackWithResponseContext(ctx, c.DeviceTypeID, c.Current.ID, input)
+		case CallbackDelete:
+			_, err = s.DeleteCallbackWithResponseContext(ctx, c.DeviceTypeID, c.Current.ID)
+		default:
+			err = fmt.Errorf("unknown action %q", c.Action)
+		}
+		if err != nil {
+			cb := c.Current
+			if cb == nil {
+				cb = &c.Desired.Callbacks
+			}
+			return errors.Wrapf(err, "%s %s of device type %s", c.Action, describeCallback(cb), c.DeviceTypeID)
+		}
+	}
+	return nil
+}
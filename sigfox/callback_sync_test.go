@@ -0,0 +1,180 @@
+package sigfox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeCallbacks serves the callbacks endpoints of a device type from memory.
+type fakeCallbacks struct {
+	mu        sync.Mutex
+	callbacks []Callbacks
+	nextID    int
+	requests  []string
+}
+
+func (f *fakeCallbacks) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.requests = append(f.requests, r.Method+" "+r.URL.Path)
+	id := strings.TrimPrefix(r.URL.Path, "/device-types/dt1/callbacks")
+	id = strings.TrimPrefix(id, "/")
+
+	var input CreateCallbackInput
+	if r.Method == "POST" || r.Method == "PUT" {
+		body, _ := ioutil.ReadAll(r.Body)
+		json.Unmarshal(body, &input)
+	}
+
+	switch r.Method {
+	case "GET":
+		json.NewEncoder(w).Encode(ListCallbacksOutput{Data: f.callbacks})
+	case "POST":
+		f.nextID++
+		input.Callbacks.ID = fmt.Sprintf("new%d", f.nextID)
+		f.callbacks = append(f.callbacks, input.Callbacks)
+		fmt.Fprintf(w, `{"id":%q}`, input.Callbacks.ID)
+	case "PUT":
+		for i := range f.callbacks {
+			if f.callbacks[i].ID == id {
+				input.Callbacks.ID = id
+				f.callbacks[i] = input.Callbacks
+			}
+		}
+	case "DELETE":
+		for i := range f.callbacks {
+			if f.callbacks[i].ID == id {
+				f.callbacks = append(f.callbacks[:i], f.callbacks[i+1:]...)
+				break
+			}
+		}
+	}
+}
+
+func TestCallbackSync(t *testing.T) {
+	fake := &fakeCallbacks{callbacks: []Callbacks{
+		{ID: "cb1", Channel: "URL", CallbackType: 0, CallbackSubtype: 2, URL: "https://example.com/a", HTTPMethod: "GET", Enabled: true, Dead: true},
+		{ID: "cb2", Channel: "URL", CallbackType: 0, CallbackSubtype: 2, URL: "https://example.com/b", HTTPMethod: "GET", Enabled: true},
+		{ID: "cb3", Channel: "EMAIL", CallbackType: 2, Recipient: "ops@example.com", Subject: "error", Message: "{info}"},
+	}}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	config, err := LoadCallbackConfig(strings.NewReader(`{"deviceTypes":{"dt1":[
+		{"channel":"URL","callbackType":0,"callbackSubtype":2,"url":"https://example.com/a","httpMethod":"POST","enabled":true,"dead":false},
+		{"channel":"URL","callbackType":0,"callbackSubtype":2,"url":"https://example.com/b","httpMethod":"GET","enabled":true,"headers":{}},
+		{"channel":"URL","callbackType":"SERVICE","callbackSubtype":"STATUS","url":"https://example.com/status","httpMethod":"POST","enabled":true,"contentType":"application/json"}
+	]}}`))
+	if err != nil {
+		t.Fatalf("LoadCallbackConfig returned error: %v", err)
+	}
+
+	c, _ := NewClient("LOGIN_ID", "PASSWORD", WithBaseURL(server.URL))
+	ctx := context.Background()
+
+	plan, err := c.DeviceType.PlanCallbacksContext(ctx, config)
+	if err != nil {
+		t.Fatalf("PlanCallbacksContext returned error: %v", err)
+	}
+
+	want := "- device type dt1: EMAIL callback ERROR ops@example.com (cb3)\n" +
+		"~ device type dt1: URL callback DATA/UPLINK https://example.com/a (cb1): httpMethod [dead]\n" +
+		"+ device type dt1: URL callback SERVICE/STATUS https://example.com/status\n" +
+		"Plan: 1 to create, 1 to update, 1 to delete.\n"
+	if got := plan.String(); got != want {
+		t.Errorf("plan is\n%s\nwant\n%s", got, want)
+	}
+
+	if err := c.DeviceType.ApplyCallbacksContext(ctx, plan); err != nil {
+		t.Fatalf("ApplyCallbacksContext returned error: %v", err)
+	}
+
+	plan, err = c.DeviceType.PlanCallbacksContext(ctx, config)
+	if err != nil {
+		t.Fatalf("PlanCallbacksContext returned error: %v", err)
+	}
+	if !plan.Empty() {
+		t.Errorf("plan after apply is not empty:\n%s", plan)
+	}
+}
+
+func TestPlanCallbacks_replace(t *testing.T) {
+	current := []Callbacks{{ID: "cb1", Channel: "URL", CallbackType: 0, CallbackSubtype: 2, URL: "https://example.com"}}
+	desired := []CreateCallbackInput{{Callbacks: Callbacks{ID: "cb1", Channel: "URL", CallbackType: 0, CallbackSubtype: 3, URL: "https://example.com"}}}
+
+	changes, err := planDeviceType("dt1", current, desired)
+	if err != nil {
+		t.Fatalf("planDeviceType returned error: %v", err)
+	}
+	if len(changes) != 2 || changes[0].Action != CallbackDelete || changes[1].Action != CallbackCreate || changes[1].Desired.ID != "" {
+		t.Errorf("planDeviceType returned %+v, want a deletion and a creation", changes)
+	}
+
+	desired[0].ID = "unknown"
+	if _, err := planDeviceType("dt1", current, desired); err == nil {
+		t.Error("planDeviceType accepted an unknown callback ID")
+	}
+}
+
+func TestPlanCallbacks_invalid(t *testing.T) {
+	fake := &fakeCallbacks{callbacks: []Callbacks{
+		{ID: "cb1", Channel: "URL", CallbackType: 0, CallbackSubtype: 2, URL: "https://example.com/a", HTTPMethod: "GET"},
+	}}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	c, _ := NewClient("LOGIN_ID", "PASSWORD", WithBaseURL(server.URL))
+	ctx := context.Background()
+
+	config := &CallbackConfig{DeviceTypes: map[string][]CreateCallbackInput{
+		"dt1": {{Callbacks: Callbacks{ID: "cb1", Channel: "URL", CallbackType: 0, CallbackSubtype: 3}}},
+	}}
+	if _, err := c.DeviceType.PlanCallbacksContext(ctx, config); !errors.Is(err, ErrValidation) {
+		t.Errorf("PlanCallbacksContext returned %v, want a validation error", err)
+	}
+
+	config.DeviceTypes["dt1"][0].URL = "https://example.com/a"
+	config.ValidateCallback = func(cb *Callbacks) error {
+		return fmt.Errorf("invalid template")
+	}
+	if _, err := c.DeviceType.PlanCallbacksContext(ctx, config); err == nil || !strings.Contains(err.Error(), "invalid template") {
+		t.Errorf("PlanCallbacksContext returned %v, want the error of ValidateCallback", err)
+	}
+
+	if len(fake.requests) != 0 {
+		t.Errorf("PlanCallbacksContext sent %v, want no request", fake.requests)
+	}
+}
+
+func TestLoadCallbackConfig_yaml(t *testing.T) {
+	config, err := LoadCallbackConfig(strings.NewReader(`
+deviceTypes:
+  dt1:
+    - channel: URL
+      callbackType: DATA
+      callbackSubtype: UPLINK
+      url: https://example.com/sigfox
+      httpMethod: POST
+      bodyTemplate: '{"device":"{device}"}'
+`))
+	if err != nil {
+		t.Fatalf("LoadCallbackConfig returned error: %v", err)
+	}
+	cb := config.DeviceTypes["dt1"][0]
+	if cb.CallbackType != CallbackTypeData || cb.CallbackSubtype != CallbackSubtypeUplink || cb.BodyTemplate != `{"device":"{device}"}` {
+		t.Errorf("LoadCallbackConfig read %+v", cb)
+	}
+
+	if _, err := LoadCallbackConfig(strings.NewReader("deviceTypes:\n  dt1:\n    - chanel: URL\n")); err == nil {
+		t.Error("LoadCallbackConfig accepted an unknown field")
+	}
+}
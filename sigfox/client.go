@@ -4,13 +4,12 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"fmt"
 	"io"
-	"io/ioutil"
 	"net/http"
 	"net/url"
 	"path"
 	"reflect"
+	"time"
 
 	"github.com/google/go-querystring/query"
 	"github.com/pkg/errors"
@@ -28,17 +27,37 @@ type Client struct {
 	Login, Password string
 	UserAgent       string
 
+	// RetryPolicy controls how failed requests are retried. Nil disables retries.
+	RetryPolicy *RetryPolicy
+	// RateLimiter throttles outgoing requests when set.
+	RateLimiter *RateLimiter
+	// Logger reports requests and retries when set.
+	Logger Logger
+
+	// RequestHooks are called before each attempt of a request is sent.
+	RequestHooks []RequestHook
+	// ResponseHooks are called after each attempt with its outcome.
+	ResponseHooks []ResponseHook
+
 	common service
 
 	ApiUser    *ApiUserService
+	Contract   *ContractService
 	Coverage   *CoverageService
 	Device     *DeviceService
 	DeviceType *DeviceTypeService
 	Group      *GroupService
 	Profile    *ProfileService
 	Tile       *TileService
+	User       *UserService
 }
 
+// RequestHook is called with the request about to be sent.
+type RequestHook func(req *http.Request)
+
+// ResponseHook is called with the response of a request or the error it failed with.
+type ResponseHook func(req *http.Request, resp *http.Response, err error)
+
 type service struct {
 	client *Client
 }
@@ -47,7 +66,9 @@ type Pagination struct {
 	Next string `json:"next"`
 }
 
-func NewClient(login, password string) (*Client, error) {
+// NewClient returns a new Sigfox API client authenticated with the API login and password.
+// The default settings can be changed with options such as WithBaseURL or WithHTTPClient.
+func NewClient(login, password string, opts ...ClientOption) (*Client, error) {
 	if len(login) == 0 {
 		return nil, errors.New("missing login key")
 	}
@@ -56,27 +77,57 @@ func NewClient(login, password string) (*Client, error) {
 		return nil, errors.New("missing login password")
 	}
 
-	parsedURL, err := url.ParseRequestURI(defaultBaseURL)
+	o := &clientOptions{baseURL: defaultBaseURL, userAgent: userAgent}
+	for _, opt := range opts {
+		if err := opt(o); err != nil {
+			return nil, errors.Wrap(err, "invalid client option")
+		}
+	}
+
+	parsedURL, err := url.ParseRequestURI(o.baseURL)
 	if err != nil {
-		return nil, errors.Wrapf(err, "failed to parse url: %s", defaultBaseURL)
+		return nil, errors.Wrapf(err, "failed to parse url: %s", o.baseURL)
+	}
+	if parsedURL.Scheme != "http" && parsedURL.Scheme != "https" {
+		return nil, errors.Errorf("unsupported url scheme: %s", o.baseURL)
 	}
 
-	c := &Client{HTTPClient: &http.Client{}, baseURL: parsedURL, UserAgent: userAgent, Login: login, Password: password}
+	retryPolicy := DefaultRetryPolicy
+	c := &Client{
+		HTTPClient:  o.buildHTTPClient(),
+		baseURL:     parsedURL,
+		UserAgent:   o.userAgent,
+		Login:       login,
+		Password:    password,
+		RetryPolicy: &retryPolicy,
+		Logger:      o.logger,
+
+		RequestHooks:  o.requestHooks,
+		ResponseHooks: o.responseHooks,
+	}
 	c.common.client = c
 	c.ApiUser = (*ApiUserService)(&c.common)
+	c.Contract = (*ContractService)(&c.common)
 	c.Coverage = (*CoverageService)(&c.common)
 	c.Device = (*DeviceService)(&c.common)
 	c.DeviceType = (*DeviceTypeService)(&c.common)
 	c.Group = (*GroupService)(&c.common)
 	c.Profile = (*ProfileService)(&c.common)
 	c.Tile = (*TileService)(&c.common)
+	c.User = (*UserService)(&c.common)
 
 	return c, nil
 }
 
 func (c *Client) newRequest(ctx context.Context, method, spath string, body interface{}) (*http.Request, error) {
+	rel, err := url.Parse(spath)
+	if err != nil {
+		return nil, err
+	}
+
 	u := *c.baseURL
-	u.Path = path.Join(c.baseURL.Path, spath)
+	u.Path = path.Join(c.baseURL.Path, rel.Path)
+	u.RawQuery = rel.RawQuery
 
 	var buf io.ReadWriter
 	if body != nil {
@@ -94,54 +145,79 @@ func (c *Client) newRequest(ctx context.Context, method, spath string, body inte
 	}
 
 	req = req.WithContext(ctx)
-	req.SetBasicAuth(c.Login, c.Password)
 	req.Header.Set("Content-Type", "application/json")
+	if c.UserAgent != "" {
+		req.Header.Set("User-Agent", c.UserAgent)
+	}
 
 	return req, nil
 }
 
-type ErrorResponse struct {
-	Response *http.Response
-	Message  string `json:"message"`
-}
-
-func (r *ErrorResponse) Error() string {
-	return fmt.Sprintf("%v %v: %d %v",
-		r.Response.Request.Method, r.Response.Request.URL, r.Response.StatusCode, r.Message)
-}
-
 // Do sends an API request and returns the API response.
+// Every service call goes through Do, which authenticates the request,
+// maps HTTP failures to typed errors, retries them according to the client
+// RetryPolicy, throttles them with its RateLimiter and runs the hooks.
 func (c *Client) Do(ctx context.Context, req *http.Request) (*http.Response, error) {
-	resp, err := c.HTTPClient.Do(req)
-	if err != nil {
-		select {
-		case <-ctx.Done():
-			return nil, ctx.Err()
-		default:
-		}
-		return nil, err
+	if _, _, ok := req.BasicAuth(); !ok {
+		req.SetBasicAuth(c.Login, c.Password)
 	}
+	attempts := c.RetryPolicy.attempts(req)
 
-	err = checkResponse(resp)
-	if err != nil {
-		return resp, err
-	}
+	for attempt := 1; ; attempt++ {
+		if c.RateLimiter != nil {
+			if err := c.RateLimiter.Wait(ctx); err != nil {
+				return nil, err
+			}
+		}
 
-	return resp, err
-}
+		for _, hook := range c.RequestHooks {
+			hook(req)
+		}
 
-func checkResponse(r *http.Response) error {
-	if c := r.StatusCode; 200 <= c && c <= 299 {
-		return nil
-	}
+		resp, err := c.HTTPClient.Do(req)
+		if err == nil {
+			err = checkResponse(resp)
+		}
+		for _, hook := range c.ResponseHooks {
+			hook(req, resp, err)
+		}
 
-	errorResponse := &ErrorResponse{Response: r}
-	data, err := ioutil.ReadAll(r.Body)
-	if err == nil && data != nil {
-		json.Unmarshal(data, errorResponse)
-	}
+		if resp == nil {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			default:
+			}
+			c.logf("sigfox: %s %s: %v (attempt %d/%d)", req.Method, req.URL, err, attempt, attempts)
+			if attempt >= attempts {
+				return nil, err
+			}
+		} else {
+			c.logf("sigfox: %s %s: %d (attempt %d/%d)", req.Method, req.URL, resp.StatusCode, attempt, attempts)
+			if err == nil {
+				return resp, nil
+			}
+			if attempt >= attempts || !retryableStatus(resp.StatusCode) {
+				return resp, err
+			}
+			resp.Body.Close()
+		}
 
-	return errorResponse
+		wait := c.RetryPolicy.backoff(attempt, resp)
+		c.logf("sigfox: retrying %s %s in %v", req.Method, req.URL, wait)
+		if err := sleepContext(ctx, wait); err != nil {
+			return nil, err
+		}
+
+		req = req.Clone(ctx)
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+	}
 }
 
 func decodeBody(resp *http.Response, out interface{}) error {
@@ -151,17 +227,20 @@ func decodeBody(resp *http.Response, out interface{}) error {
 		io.Copy(w, resp.Body)
 	}
 	decoder := json.NewDecoder(resp.Body)
-	return decoder.Decode(out)
+	if err := decoder.Decode(out); err != nil && err != io.EOF {
+		return err
+	}
+	return nil
 }
 
 type QueryParams struct {
-	Fields    string   `url:"fields,omitempty"`
-	Since     int64    `url:"since,omitempty"`
-	Before    int64    `url:"before,omitempty"`
-	Limit     int      `url:"limit,omitempty"`
-	Offset    int32    `url:"offset,omitempty"`
-	ProfileID string   `url:"profileId,omitempty"`
-	GroupIds  []string `url:"groupIds,omitempty"`
+	Fields    string    `url:"fields,omitempty"`
+	Since     Timestamp `url:"since,omitempty"`
+	Before    Timestamp `url:"before,omitempty"`
+	Limit     int       `url:"limit,omitempty"`
+	Offset    int32     `url:"offset,omitempty"`
+	ProfileID string    `url:"profileId,omitempty"`
+	GroupIds  []string  `url:"groupIds,omitempty"`
 }
 
 type QueryParam func(*QueryParams)
@@ -170,12 +249,24 @@ func Fields(s string) QueryParam {
 	return func(q *QueryParams) { q.Fields = s }
 }
 
+// Since filters the items after a time given in milliseconds since the Unix Epoch.
 func Since(i int64) QueryParam {
-	return func(q *QueryParams) { q.Since = i }
+	return func(q *QueryParams) { q.Since = Timestamp(i) }
 }
 
+// Before filters the items before a time given in milliseconds since the Unix Epoch.
 func Before(i int64) QueryParam {
-	return func(q *QueryParams) { q.Before = i }
+	return func(q *QueryParams) { q.Before = Timestamp(i) }
+}
+
+// SinceTime filters the items after t.
+func SinceTime(t time.Time) QueryParam {
+	return func(q *QueryParams) { q.Since = NewTimestamp(t) }
+}
+
+// BeforeTime filters the items before t.
+func BeforeTime(t time.Time) QueryParam {
+	return func(q *QueryParams) { q.Before = NewTimestamp(t) }
 }
 
 func Limit(i int) QueryParam {
@@ -2,7 +2,9 @@ package sigfox
 
 import (
 	"context"
+	"net/http"
 	"testing"
+	"time"
 )
 
 func TestNewClient(t *testing.T) {
@@ -25,3 +27,66 @@ func TestNewRequest(t *testing.T) {
 		t.Errorf("newRequest(%q) URL is %v, want %v", inURL, got, want)
 	}
 }
+
+func TestNewRequest_query(t *testing.T) {
+	c, _ := NewClient("LOGIN_ID", "PASSWORD")
+	ctx := context.Background()
+
+	inURL, outURL := "/foo?limit=10&offset=20", defaultBaseURL+"/foo?limit=10&offset=20"
+	req, _ := c.newRequest(ctx, "GET", inURL, nil)
+
+	if got, want := req.URL.String(), outURL; got != want {
+		t.Errorf("newRequest(%q) URL is %v, want %v", inURL, got, want)
+	}
+}
+
+func TestNewClient_options(t *testing.T) {
+	var wrapped bool
+	hc := &http.Client{}
+	c, err := NewClient("LOGIN_ID", "PASSWORD",
+		WithBaseURL("http://localhost:8080/v2"),
+		WithHTTPClient(hc),
+		WithUserAgent("test-agent"),
+		WithTimeout(5*time.Second),
+		WithTransport(func(rt http.RoundTripper) http.RoundTripper {
+			wrapped = true
+			return rt
+		}),
+	)
+	if err != nil {
+		t.Fatalf("NewClient returned error: %v", err)
+	}
+
+	if got, want := c.baseURL.String(), "http://localhost:8080/v2"; got != want {
+		t.Errorf("NewClient baseURL is %v, want %v", got, want)
+	}
+	if got, want := c.UserAgent, "test-agent"; got != want {
+		t.Errorf("NewClient UserAgent is %v, want %v", got, want)
+	}
+	if got, want := c.HTTPClient.Timeout, 5*time.Second; got != want {
+		t.Errorf("NewClient HTTPClient.Timeout is %v, want %v", got, want)
+	}
+	if hc.Timeout != 0 {
+		t.Error("NewClient modified the given http client")
+	}
+	if !wrapped {
+		t.Error("NewClient did not apply the transport middleware")
+	}
+}
+
+func TestNewClient_invalidOptions(t *testing.T) {
+	opts := []ClientOption{
+		WithBaseURL("not a url"),
+		WithBaseURL("ftp://example.com"),
+		WithHTTPClient(nil),
+		WithUserAgent(""),
+		WithTimeout(-time.Second),
+		WithTransport(nil),
+	}
+
+	for _, opt := range opts {
+		if _, err := NewClient("LOGIN_ID", "PASSWORD", opt); err == nil {
+			t.Errorf("NewClient succeeded with an invalid option")
+		}
+	}
+}
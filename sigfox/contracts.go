@@ -0,0 +1,148 @@
+package sigfox
+
+import (
+	"context"
+	"fmt"
+)
+
+type ContractService service
+
+// ContractInfo is a contract of a group, whose tokens let devices communicate.
+// The contract of a device type only holds its ID and name.
+type ContractInfo struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+	// ContractID is the ID of the contract in the order system.
+	ContractID string       `json:"contractId,omitempty"`
+	UserID     string       `json:"userId,omitempty"`
+	Group      MinimalGroup `json:"group,omitempty"`
+	// StartTime is the time the contract starts, and ActivationEndTime the time
+	// after which no token of the contract can be activated.
+	StartTime         Timestamp `json:"startTime,omitempty"`
+	ActivationEndTime Timestamp `json:"activationEndTime,omitempty"`
+	// CommunicationEndTime is the time after which the devices of the contract cannot communicate.
+	CommunicationEndTime Timestamp `json:"communicationEndTime,omitempty"`
+	Timezone             string    `json:"timezone,omitempty"`
+	// PricingModel is the version of the pricing model of the contract, from 1 to 3.
+	PricingModel     int32 `json:"pricingModel,omitempty"`
+	SubscriptionPlan int32 `json:"subscriptionPlan,omitempty"`
+	Bidir            bool  `json:"bidir,omitempty"`
+	// MaxUplinkFrames and MaxDownlinkFrames are the numbers of messages a device
+	// may send and receive per day. Zero means no limit.
+	MaxUplinkFrames   int32 `json:"maxUplinkFrames,omitempty"`
+	MaxDownlinkFrames int32 `json:"maxDownlinkFrames,omitempty"`
+	// MaxTokens is the number of tokens of the contract, zero meaning no limit.
+	MaxTokens int32 `json:"maxTokens,omitempty"`
+	// TokensInUse is the number of tokens held by devices, and TokensUsed the number
+	// of tokens consumed, including expired ones.
+	TokensInUse int32 `json:"tokensInUse,omitempty"`
+	TokensUsed  int32 `json:"tokensUsed,omitempty"`
+	// TokenDuration is the validity of the tokens in months.
+	TokenDuration    int32     `json:"tokenDuration,omitempty"`
+	AutomaticRenewal bool      `json:"automaticRenewal,omitempty"`
+	RenewalDuration  int32     `json:"renewalDuration,omitempty"`
+	CreationTime     Timestamp `json:"creationTime,omitempty"`
+	CreatedBy        string    `json:"createdBy,omitempty"`
+	LastEditionTime  Timestamp `json:"lastEditionTime,omitempty"`
+	LastEditedBy     string    `json:"lastEditedBy,omitempty"`
+}
+
+// AvailableTokens returns the number of tokens which can still be assigned,
+// and false if the contract has no limit.
+func (c *ContractInfo) AvailableTokens() (int32, bool) {
+	if c.MaxTokens == 0 {
+		return 0, false
+	}
+	return c.MaxTokens - c.TokensInUse, true
+}
+
+type ListContractInfosOptions struct {
+	Name       string   `url:"name,omitempty"`
+	GroupID    string   `url:"groupId,omitempty"`
+	ContractID string   `url:"contractId,omitempty"`
+	UserID     string   `url:"userId,omitempty"`
+	Fields     []string `url:"fields,omitempty"`
+	Limit      int32    `url:"limit,omitempty"`
+	Offset     int32    `url:"offset,omitempty"`
+}
+
+type ListContractInfosOutput struct {
+	Data   []ContractInfo `json:"data"`
+	Paging Pagination     `json:"paging"`
+}
+
+// ListWithResponse retrieve a list of contracts according to visibility permissions and request filters.
+func (s *ContractService) ListWithResponse(opt *ListContractInfosOptions) (*ListContractInfosOutput, *Response, error) {
+	return s.ListWithResponseContext(context.Background(), opt)
+}
+
+// ListWithResponseContext retrieve a list of contracts according to visibility permissions and request filters with context.
+func (s *ContractService) ListWithResponseContext(ctx context.Context, opt *ListContractInfosOptions) (*ListContractInfosOutput, *Response, error) {
+	spath, err := addOptions("/contract-infos", opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var out ListContractInfosOutput
+	res, err := s.client.call(ctx, "GET", spath, nil, &out)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return &out, res, nil
+}
+
+// InfoWithResponse retrieve information about a contract.
+func (s *ContractService) InfoWithResponse(contractID string, params ...QueryParam) (*ContractInfo, *Response, error) {
+	return s.InfoWithResponseContext(context.Background(), contractID, params...)
+}
+
+// InfoWithResponseContext retrieve information about a contract with context.
+func (s *ContractService) InfoWithResponseContext(ctx context.Context, contractID string, params ...QueryParam) (*ContractInfo, *Response, error) {
+	opt := &QueryParams{}
+	for _, param := range params {
+		param(opt)
+	}
+
+	spath := fmt.Sprintf("/contract-infos/%s", contractID)
+	spath, err := addOptions(spath, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var out ContractInfo
+	res, err := s.client.call(ctx, "GET", spath, nil, &out)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return &out, res, nil
+}
+
+type ListContractDevicesOptions struct {
+	Fields []string `url:"fields,omitempty"`
+	Limit  int32    `url:"limit,omitempty"`
+	Offset int32    `url:"offset,omitempty"`
+}
+
+// ListDevicesWithResponse retrieve a list of the devices holding a token of a contract.
+func (s *ContractService) ListDevicesWithResponse(contractID string, opt *ListContractDevicesOptions) (*ListDevices, *Response, error) {
+	return s.ListDevicesWithResponseContext(context.Background(), contractID, opt)
+}
+
+// ListDevicesWithResponseContext retrieve a list of the devices holding a token of a contract with context.
+func (s *ContractService) ListDevicesWithResponseContext(ctx context.Context, contractID string, opt *ListContractDevicesOptions) (*ListDevices, *Response, error) {
+	spath := fmt.Sprintf("/contract-infos/%s/devices", contractID)
+	spath, err := addOptions(spath, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var out ListDevices
+	res, err := s.client.call(ctx, "GET", spath, nil, &out)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return &out, res, nil
+}
@@ -0,0 +1,85 @@
+package sigfox
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestContractService_Info(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/contract-infos/c1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":"c1","name":"Contract","contractId":"ORD-1","group":{"id":"g1","name":"Group","type":2},
+			"startTime":1577836800000,"activationEndTime":1609459200000,"communicationEndTime":1640995200000,
+			"pricingModel":3,"maxUplinkFrames":140,"maxDownlinkFrames":4,"maxTokens":100,"tokensInUse":40,"tokensUsed":55}`)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	c, _ := NewClient("LOGIN_ID", "PASSWORD", WithBaseURL(server.URL))
+
+	got, _, err := c.Contract.InfoWithResponseContext(context.Background(), "c1")
+	if err != nil {
+		t.Fatalf("InfoContext returned error: %v", err)
+	}
+
+	want := &ContractInfo{
+		ID:                   "c1",
+		Name:                 "Contract",
+		ContractID:           "ORD-1",
+		Group:                MinimalGroup{ID: "g1", Name: "Group", Type: GroupTypeOther},
+		StartTime:            1577836800000,
+		ActivationEndTime:    1609459200000,
+		CommunicationEndTime: 1640995200000,
+		PricingModel:         3,
+		MaxUplinkFrames:      140,
+		MaxDownlinkFrames:    4,
+		MaxTokens:            100,
+		TokensInUse:          40,
+		TokensUsed:           55,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("InfoContext returned %+v, want %+v", got, want)
+	}
+
+	if n, ok := got.AvailableTokens(); n != 60 || !ok {
+		t.Errorf("AvailableTokens() = %d, %v, want 60, true", n, ok)
+	}
+	if _, ok := (&ContractInfo{}).AvailableTokens(); ok {
+		t.Errorf("AvailableTokens() of a contract without limit returned true")
+	}
+}
+
+func TestContractService_IterateDevices(t *testing.T) {
+	var server *httptest.Server
+	mux := http.NewServeMux()
+	mux.HandleFunc("/contract-infos/c1/devices", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("offset") {
+		case "":
+			fmt.Fprintf(w, `{"data":[{"id":"1"},{"id":"2"}],"paging":{"next":"%s/contract-infos/c1/devices?limit=2&offset=2"}}`, server.URL)
+		case "2":
+			fmt.Fprint(w, `{"data":[{"id":"3"}],"paging":{}}`)
+		default:
+			t.Errorf("unexpected offset %q", r.URL.Query().Get("offset"))
+		}
+	})
+	server = httptest.NewServer(mux)
+	defer server.Close()
+
+	c, _ := NewClient("LOGIN_ID", "PASSWORD", WithBaseURL(server.URL))
+
+	it := c.Contract.IterateDevices(context.Background(), "c1", &ListContractDevicesOptions{Limit: 2}, nil)
+	var ids []string
+	for it.Next() {
+		ids = append(ids, it.Value().ID)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("IterateDevices returned error: %v", err)
+	}
+	if got, want := fmt.Sprint(ids), "[1 2 3]"; got != want {
+		t.Errorf("IterateDevices yielded %v, want %v", got, want)
+	}
+}
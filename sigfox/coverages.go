@@ -2,8 +2,6 @@ package sigfox
 
 import (
 	"context"
-	"fmt"
-	"net/http"
 )
 
 type CoverageService service
@@ -20,31 +18,21 @@ type CoveragePredictionOutput struct {
 	Margins         []int `json:"margins,omitempty"`
 }
 
-// Predictions retrieve coverage predictions for any location.
-func (s *CoverageService) Predictions(input *CoveragePredictionInput) (*CoveragePredictionOutput, *http.Response, error) {
-	return s.PredictionsContext(context.Background(), input)
+// PredictionsWithResponse retrieve coverage predictions for any location.
+func (s *CoverageService) PredictionsWithResponse(input *CoveragePredictionInput) (*CoveragePredictionOutput, *Response, error) {
+	return s.PredictionsWithResponseContext(context.Background(), input)
 }
 
-// PredictionsContext retrieve coverage predictions for any location with context.
-func (s *CoverageService) PredictionsContext(ctx context.Context, input *CoveragePredictionInput) (*CoveragePredictionOutput, *http.Response, error) {
-	spath := fmt.Sprintf("/coverages/global/predictions")
-	spath, err := addOptions(spath, input)
+// PredictionsWithResponseContext retrieve coverage predictions for any location with context.
+func (s *CoverageService) PredictionsWithResponseContext(ctx context.Context, input *CoveragePredictionInput) (*CoveragePredictionOutput, *Response, error) {
+	spath, err := addOptions("/coverages/global/predictions", input)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	req, err := s.client.newRequest(ctx, "GET", spath, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	res, err := s.client.Do(ctx, req)
-	if err != nil {
-		return nil, res, err
-	}
-
 	var out CoveragePredictionOutput
-	if err := decodeBody(res, &out); err != nil {
+	res, err := s.client.call(ctx, "GET", spath, nil, &out)
+	if err != nil {
 		return nil, res, err
 	}
 
@@ -69,25 +57,16 @@ type CoverageBatchPredictionOutput struct {
 	} `json:"data,omitempty"`
 }
 
-// BatchPredictions retrieve coverage predictions for any batch of locations.
-func (s *CoverageService) BatchPredictions(input *CoverageBatchPredictionInput) (*CoverageBatchPredictionOutput, *http.Response, error) {
-	return s.BatchPredictionsContext(context.Background(), input)
+// BatchPredictionsWithResponse retrieve coverage predictions for any batch of locations.
+func (s *CoverageService) BatchPredictionsWithResponse(input *CoverageBatchPredictionInput) (*CoverageBatchPredictionOutput, *Response, error) {
+	return s.BatchPredictionsWithResponseContext(context.Background(), input)
 }
 
-// BatchPredictionsContext retrieve coverage predictions for any batch of locations with context.
-func (s *CoverageService) BatchPredictionsContext(ctx context.Context, input *CoverageBatchPredictionInput) (*CoverageBatchPredictionOutput, *http.Response, error) {
-	req, err := s.client.newRequest(ctx, "POST", "/coverages/global/predictions", input)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	res, err := s.client.Do(ctx, req)
-	if err != nil {
-		return nil, res, err
-	}
-
+// BatchPredictionsWithResponseContext retrieve coverage predictions for any batch of locations with context.
+func (s *CoverageService) BatchPredictionsWithResponseContext(ctx context.Context, input *CoverageBatchPredictionInput) (*CoverageBatchPredictionOutput, *Response, error) {
 	var out CoverageBatchPredictionOutput
-	if err = decodeBody(res, &out); err != nil {
+	res, err := s.client.call(ctx, "POST", "/coverages/global/predictions", input, &out)
+	if err != nil {
 		return nil, res, err
 	}
 
@@ -106,31 +85,21 @@ type CoverageRedundancyOutput struct {
 	Redundancy int `json:"redundancy,omitempty"`
 }
 
-// Redundancy retrieve coverage redundancy for an operator.
-func (s *CoverageService) Redundancy(input *CoverageRedundancyInput) (*CoverageRedundancyOutput, *http.Response, error) {
-	return s.RedundancyContext(context.Background(), input)
+// RedundancyWithResponse retrieve coverage redundancy for an operator.
+func (s *CoverageService) RedundancyWithResponse(input *CoverageRedundancyInput) (*CoverageRedundancyOutput, *Response, error) {
+	return s.RedundancyWithResponseContext(context.Background(), input)
 }
 
-// RedundancyContext retrieve coverage redundancy for an operator with context.
-func (s *CoverageService) RedundancyContext(ctx context.Context, input *CoverageRedundancyInput) (*CoverageRedundancyOutput, *http.Response, error) {
-	spath := fmt.Sprintf("/coverages/operators/redundancy")
-	spath, err := addOptions(spath, input)
+// RedundancyWithResponseContext retrieve coverage redundancy for an operator with context.
+func (s *CoverageService) RedundancyWithResponseContext(ctx context.Context, input *CoverageRedundancyInput) (*CoverageRedundancyOutput, *Response, error) {
+	spath, err := addOptions("/coverages/operators/redundancy", input)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	req, err := s.client.newRequest(ctx, "GET", spath, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	res, err := s.client.Do(ctx, req)
-	if err != nil {
-		return nil, res, err
-	}
-
 	var out CoverageRedundancyOutput
-	if err := decodeBody(res, &out); err != nil {
+	res, err := s.client.call(ctx, "GET", spath, nil, &out)
+	if err != nil {
 		return nil, res, err
 	}
 
@@ -0,0 +1,378 @@
+package sigfox
+
+import (
+	"context"
+	"net/http"
+)
+
+// The methods below keep the signatures of the previous releases. They return
+// the *http.Response, or no response at all, where the methods they wrap return
+// a *Response.
+
+// httpResponse returns the HTTP response wrapped by r, if any.
+func httpResponse(r *Response) *http.Response {
+	if r == nil {
+		return nil
+	}
+	return r.Response
+}
+
+// List retrieve a list of devices according to visibility permissions and request filters.
+//
+// Deprecated: use ListWithResponse, which also returns the response.
+func (s *DeviceService) List(opt *DeviceListOptions) (*ListDevices, error) {
+	out, _, err := s.ListWithResponse(opt)
+	return out, err
+}
+
+// ListContext retrieve a list of devices according to visibility permissions and request filters with context.
+//
+// Deprecated: use ListWithResponseContext, which also returns the response.
+func (s *DeviceService) ListContext(ctx context.Context, opt *DeviceListOptions) (*ListDevices, error) {
+	out, _, err := s.ListWithResponseContext(ctx, opt)
+	return out, err
+}
+
+// Create a new device.
+//
+// Deprecated: use CreateWithResponse, which also returns the response.
+func (s *DeviceService) Create(body *CreateDeviceBody) (*CreateDeviceOutput, error) {
+	out, _, err := s.CreateWithResponse(body)
+	return out, err
+}
+
+// CreateContext a new device with context.
+//
+// Deprecated: use CreateWithResponseContext, which also returns the response.
+func (s *DeviceService) CreateContext(ctx context.Context, body *CreateDeviceBody) (*CreateDeviceOutput, error) {
+	out, _, err := s.CreateWithResponseContext(ctx, body)
+	return out, err
+}
+
+// Info retrieve information about a device.
+//
+// Deprecated: use InfoWithResponse, which also returns the response.
+func (s *DeviceService) Info(deviceID string) (*Device, error) {
+	out, _, err := s.InfoWithResponse(deviceID)
+	return out, err
+}
+
+// InfoContext retrieve information about a device with context.
+//
+// Deprecated: use InfoWithResponseContext, which also returns the response.
+func (s *DeviceService) InfoContext(ctx context.Context, deviceID string) (*Device, error) {
+	out, _, err := s.InfoWithResponseContext(ctx, deviceID)
+	return out, err
+}
+
+// Update a device.
+//
+// Deprecated: use UpdateWithResponse, which also returns the response.
+func (s *DeviceService) Update(deviceID string, body *UpdateDeviceBody) error {
+	_, err := s.UpdateWithResponse(deviceID, body)
+	return err
+}
+
+// UpdateContext a device with context.
+//
+// Deprecated: use UpdateWithResponseContext, which also returns the response.
+func (s *DeviceService) UpdateContext(ctx context.Context, deviceID string, body *UpdateDeviceBody) error {
+	_, err := s.UpdateWithResponseContext(ctx, deviceID, body)
+	return err
+}
+
+// ListUndeliveredCallbacks retrieve a list of undelivered callback messages for a given device.
+//
+// Deprecated: use ListUndeliveredCallbacksWithResponse, which also returns the response.
+func (s *DeviceService) ListUndeliveredCallbacks(deviceID string, opt *UndeliveredCallbacksOptions) (*UndeliveredCallbacks, error) {
+	out, _, err := s.ListUndeliveredCallbacksWithResponse(deviceID, opt)
+	return out, err
+}
+
+// ListUndeliveredCallbacksContext retrieve a list of undelivered callback messages for a given device with context.
+//
+// Deprecated: use ListUndeliveredCallbacksWithResponseContext, which also returns the response.
+func (s *DeviceService) ListUndeliveredCallbacksContext(ctx context.Context, deviceID string, opt *UndeliveredCallbacksOptions) (*UndeliveredCallbacks, error) {
+	out, _, err := s.ListUndeliveredCallbacksWithResponseContext(ctx, deviceID, opt)
+	return out, err
+}
+
+// DisengageSequenceNumber disengage sequence number check for next message of a device.
+//
+// Deprecated: use DisengageSequenceNumberWithResponse, which also returns the response.
+func (s *DeviceService) DisengageSequenceNumber(deviceID string) error {
+	_, err := s.DisengageSequenceNumberWithResponse(deviceID)
+	return err
+}
+
+// DisengageSequenceNumberContext disengage sequence number check for next message of a device with context.
+//
+// Deprecated: use DisengageSequenceNumberWithResponseContext, which also returns the response.
+func (s *DeviceService) DisengageSequenceNumberContext(ctx context.Context, deviceID string) error {
+	_, err := s.DisengageSequenceNumberWithResponseContext(ctx, deviceID)
+	return err
+}
+
+// Messages retrieve a list of messages for a given device with a 3-day history.
+//
+// Deprecated: use MessagesWithResponse, which also returns the response.
+func (s *DeviceService) Messages(deviceID string, params ...QueryParam) (*DeviceMessages, error) {
+	out, _, err := s.MessagesWithResponse(deviceID, params...)
+	return out, err
+}
+
+// MessagesContext retrieve a list of messages for a given device with a 3-day history with context.
+//
+// Deprecated: use MessagesWithResponseContext, which also returns the response.
+func (s *DeviceService) MessagesContext(ctx context.Context, deviceID string, params ...QueryParam) (*DeviceMessages, error) {
+	out, _, err := s.MessagesWithResponseContext(ctx, deviceID, params...)
+	return out, err
+}
+
+// Metric retrieve the number of messages for a given device.
+//
+// Deprecated: use MetricWithResponse, which also returns the response.
+func (s *DeviceService) Metric(deviceID string) (*DeviceMetric, error) {
+	out, _, err := s.MetricWithResponse(deviceID)
+	return out, err
+}
+
+// MetricContext retrieve the number of messages for a given device with context.
+//
+// Deprecated: use MetricWithResponseContext, which also returns the response.
+func (s *DeviceService) MetricContext(ctx context.Context, deviceID string) (*DeviceMetric, error) {
+	out, _, err := s.MetricWithResponseContext(ctx, deviceID)
+	return out, err
+}
+
+// CreateMultipleWithAsync create multiple new devices with asynchronous job.
+//
+// Deprecated: use CreateMultipleWithAsyncWithResponse, which also returns the response.
+func (s *DeviceService) CreateMultipleWithAsync(body *CreateMultipleDevicesBody) (*CreateMultipleDevicesOutput, error) {
+	out, _, err := s.CreateMultipleWithAsyncWithResponse(body)
+	return out, err
+}
+
+// CreateMultipleWithAsyncContext create multiple new devices with asynchronous job with context.
+//
+// Deprecated: use CreateMultipleWithAsyncWithResponseContext, which also returns the response.
+func (s *DeviceService) CreateMultipleWithAsyncContext(ctx context.Context, body *CreateMultipleDevicesBody) (*CreateMultipleDevicesOutput, error) {
+	out, _, err := s.CreateMultipleWithAsyncWithResponseContext(ctx, body)
+	return out, err
+}
+
+// List retrieve a list of device types according to visibility permissions and request filters.
+//
+// Deprecated: use ListWithResponse, which returns a *Response.
+func (s *DeviceTypeService) List(opt *ListDeviceTypesOptions) (*ListDeviceTypesOutput, *http.Response, error) {
+	out, res, err := s.ListWithResponse(opt)
+	return out, httpResponse(res), err
+}
+
+// ListContext retrieve a list of device types according to visibility permissions and request filters with context.
+//
+// Deprecated: use ListWithResponseContext, which returns a *Response.
+func (s *DeviceTypeService) ListContext(ctx context.Context, opt *ListDeviceTypesOptions) (*ListDeviceTypesOutput, *http.Response, error) {
+	out, res, err := s.ListWithResponseContext(ctx, opt)
+	return out, httpResponse(res), err
+}
+
+// Create a new device type.
+//
+// Deprecated: use CreateWithResponse, which returns a *Response.
+func (s *DeviceTypeService) Create(input *CreateDeviceTypeInput) (*CreateDeviceTypeOutput, *http.Response, error) {
+	out, res, err := s.CreateWithResponse(input)
+	return out, httpResponse(res), err
+}
+
+// CreateContext a new device type with context.
+//
+// Deprecated: use CreateWithResponseContext, which returns a *Response.
+func (s *DeviceTypeService) CreateContext(ctx context.Context, input *CreateDeviceTypeInput) (*CreateDeviceTypeOutput, *http.Response, error) {
+	out, res, err := s.CreateWithResponseContext(ctx, input)
+	return out, httpResponse(res), err
+}
+
+// Info retrieve information about a device type.
+//
+// Deprecated: use InfoWithResponse, which returns a *Response.
+func (s *DeviceTypeService) Info(deviceTypeID string, params ...QueryParam) (*DeviceType, *http.Response, error) {
+	out, res, err := s.InfoWithResponse(deviceTypeID, params...)
+	return out, httpResponse(res), err
+}
+
+// InfoContext retrieve information abount a device type with context.
+//
+// Deprecated: use InfoWithResponseContext, which returns a *Response.
+func (s *DeviceTypeService) InfoContext(ctx context.Context, deviceTypeID string, params ...QueryParam) (*DeviceType, *http.Response, error) {
+	out, res, err := s.InfoWithResponseContext(ctx, deviceTypeID, params...)
+	return out, httpResponse(res), err
+}
+
+// Delete a device type.
+//
+// Deprecated: use DeleteWithResponseContext, which returns a *Response.
+func (s *DeviceTypeService) Delete(ctx context.Context, deviceTypeID string) (*http.Response, error) {
+	res, err := s.DeleteWithResponseContext(ctx, deviceTypeID)
+	return httpResponse(res), err
+}
+
+// ListMessages retrieve a list of messages for a given device types with a 3-day history.
+//
+// Deprecated: use ListMessagesWithResponseContext, which returns a *Response.
+func (s *DeviceTypeService) ListMessages(ctx context.Context, deviceTypeID string, params ...QueryParam) (*ListMessagesForDeviceTypeOutput, *http.Response, error) {
+	out, res, err := s.ListMessagesWithResponseContext(ctx, deviceTypeID, params...)
+	return out, httpResponse(res), err
+}
+
+// ListCallbackErrors retrieve a list of undelivered callback messages for a given device types.
+//
+// Deprecated: use ListCallbackErrorsWithResponseContext, which returns a *Response.
+func (s *DeviceTypeService) ListCallbackErrors(ctx context.Context, deviceTypeID string, opt *ListCallbackErrorsOptions) (*ListCallbackErrorsOutput, *http.Response, error) {
+	out, res, err := s.ListCallbackErrorsWithResponseContext(ctx, deviceTypeID, opt)
+	return out, httpResponse(res), err
+}
+
+// ListCallbacks retrieve a list of callbacks for a given device type according to visibility permissions and request filters.
+//
+// Deprecated: use ListCallbacksWithResponseContext, which returns a *Response.
+func (s *DeviceTypeService) ListCallbacks(ctx context.Context, deviceTypeID string) (*ListCallbacksOutput, *http.Response, error) {
+	out, res, err := s.ListCallbacksWithResponseContext(ctx, deviceTypeID)
+	return out, httpResponse(res), err
+}
+
+// CreateCallback create a new callback for a given device type.
+//
+// Deprecated: use CreateCallbackWithResponseContext, which returns a *Response.
+func (s *DeviceTypeService) CreateCallback(ctx context.Context, input *CreateCallbackInput) (*CreateCallbackOutput, *http.Response, error) {
+	out, res, err := s.CreateCallbackWithResponseContext(ctx, input.ID, input)
+	return out, httpResponse(res), err
+}
+
+// UpdateCallback update a callback for a given device type.
+//
+// Deprecated: use UpdateCallbackWithResponseContext, which returns a *Response.
+func (s *DeviceTypeService) UpdateCallback(ctx context.Context, deviceTypeID, callbackID string, input *UpdateCallbackInput) (*http.Response, error) {
+	res, err := s.UpdateCallbackWithResponseContext(ctx, deviceTypeID, callbackID, input)
+	return httpResponse(res), err
+}
+
+// List retrieve a list of groups according to visibility permissions and request filters.
+//
+// Deprecated: use ListWithResponseContext, which also returns the response.
+func (s *GroupService) List(ctx context.Context, opt *ListGroupsOptions) (*ListGroupsOutput, error) {
+	out, _, err := s.ListWithResponseContext(ctx, opt)
+	return out, err
+}
+
+// List retrieve a list of API users according to visibility permissions and request filters.
+//
+// Deprecated: use ListWithResponse, which returns a *Response.
+func (s *ApiUserService) List(params ...QueryParam) (*ListApiUsersOutput, *http.Response, error) {
+	out, res, err := s.ListWithResponse(params...)
+	return out, httpResponse(res), err
+}
+
+// ListContext retrieve a list of API users according to visibility permissions and request filters with context.
+//
+// Deprecated: use ListWithResponseContext, which returns a *Response.
+func (s *ApiUserService) ListContext(ctx context.Context, params ...QueryParam) (*ListApiUsersOutput, *http.Response, error) {
+	out, res, err := s.ListWithResponseContext(ctx, params...)
+	return out, httpResponse(res), err
+}
+
+// Info retrieve information about an API user.
+//
+// Deprecated: use InfoWithResponse, which returns a *Response.
+func (s *ApiUserService) Info(ApiUserID string, params ...QueryParam) (*ApiUser, *http.Response, error) {
+	out, res, err := s.InfoWithResponse(ApiUserID, params...)
+	return out, httpResponse(res), err
+}
+
+// InfoContext retrieve information about an API user with context.
+//
+// Deprecated: use InfoWithResponseContext, which returns a *Response.
+func (s *ApiUserService) InfoContext(ctx context.Context, ApiUserID string, params ...QueryParam) (*ApiUser, *http.Response, error) {
+	out, res, err := s.InfoWithResponseContext(ctx, ApiUserID, params...)
+	return out, httpResponse(res), err
+}
+
+// Predictions retrieve coverage predictions for any location.
+//
+// Deprecated: use PredictionsWithResponse, which returns a *Response.
+func (s *CoverageService) Predictions(input *CoveragePredictionInput) (*CoveragePredictionOutput, *http.Response, error) {
+	out, res, err := s.PredictionsWithResponse(input)
+	return out, httpResponse(res), err
+}
+
+// PredictionsContext retrieve coverage predictions for any location with context.
+//
+// Deprecated: use PredictionsWithResponseContext, which returns a *Response.
+func (s *CoverageService) PredictionsContext(ctx context.Context, input *CoveragePredictionInput) (*CoveragePredictionOutput, *http.Response, error) {
+	out, res, err := s.PredictionsWithResponseContext(ctx, input)
+	return out, httpResponse(res), err
+}
+
+// BatchPredictions retrieve coverage predictions for any batch of locations.
+//
+// Deprecated: use BatchPredictionsWithResponse, which returns a *Response.
+func (s *CoverageService) BatchPredictions(input *CoverageBatchPredictionInput) (*CoverageBatchPredictionOutput, *http.Response, error) {
+	out, res, err := s.BatchPredictionsWithResponse(input)
+	return out, httpResponse(res), err
+}
+
+// BatchPredictionsContext retrieve coverage predictions for any batch of locations with context.
+//
+// Deprecated: use BatchPredictionsWithResponseContext, which returns a *Response.
+func (s *CoverageService) BatchPredictionsContext(ctx context.Context, input *CoverageBatchPredictionInput) (*CoverageBatchPredictionOutput, *http.Response, error) {
+	out, res, err := s.BatchPredictionsWithResponseContext(ctx, input)
+	return out, httpResponse(res), err
+}
+
+// Redundancy retrieve coverage redundancy for an operator.
+//
+// Deprecated: use RedundancyWithResponse, which returns a *Response.
+func (s *CoverageService) Redundancy(input *CoverageRedundancyInput) (*CoverageRedundancyOutput, *http.Response, error) {
+	out, res, err := s.RedundancyWithResponse(input)
+	return out, httpResponse(res), err
+}
+
+// RedundancyContext retrieve coverage redundancy for an operator with context.
+//
+// Deprecated: use RedundancyWithResponseContext, which returns a *Response.
+func (s *CoverageService) RedundancyContext(ctx context.Context, input *CoverageRedundancyInput) (*CoverageRedundancyOutput, *http.Response, error) {
+	out, res, err := s.RedundancyWithResponseContext(ctx, input)
+	return out, httpResponse(res), err
+}
+
+// List retrieve a list of a Group's profiles according to visibility permissions and request filters.
+//
+// Deprecated: use ListWithResponse, which returns a *Response.
+func (s *ProfileService) List(input *ListProfilesInput) (*ListProfilesOutput, *http.Response, error) {
+	out, res, err := s.ListWithResponse(input)
+	return out, httpResponse(res), err
+}
+
+// ListContext retrieve a list of a Group's profiles according to visibility permissions and request filters with context.
+//
+// Deprecated: use ListWithResponseContext, which returns a *Response.
+func (s *ProfileService) ListContext(ctx context.Context, input *ListProfilesInput) (*ListProfilesOutput, *http.Response, error) {
+	out, res, err := s.ListWithResponseContext(ctx, input)
+	return out, httpResponse(res), err
+}
+
+// Monarch retrieve the information needed to display Sigfox Monarch service coverage.
+//
+// Deprecated: use MonarchWithResponse, which returns a *Response.
+func (s *TileService) Monarch() (*TileMonarchOutput, *http.Response, error) {
+	out, res, err := s.MonarchWithResponse()
+	return out, httpResponse(res), err
+}
+
+// MonarchContext retrieve the information needed to display Sigfox Monarch service coverage with context.
+//
+// Deprecated: use MonarchWithResponseContext, which returns a *Response.
+func (s *TileService) MonarchContext(ctx context.Context) (*TileMonarchOutput, *http.Response, error) {
+	out, res, err := s.MonarchWithResponseContext(ctx)
+	return out, httpResponse(res), err
+}
@@ -0,0 +1,78 @@
+package sigfox
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// The signatures of the previous releases must keep compiling.
+var (
+	_ func(*DeviceListOptions) (*ListDevices, error)                                             = (*DeviceService)(nil).List
+	_ func(context.Context, string) (*Device, error)                                             = (*DeviceService)(nil).InfoContext
+	_ func(string, *UpdateDeviceBody) error                                                      = (*DeviceService)(nil).Update
+	_ func(context.Context, string) error                                                        = (*DeviceService)(nil).DisengageSequenceNumberContext
+	_ func(context.Context, *ListGroupsOptions) (*ListGroupsOutput, error)                       = (*GroupService)(nil).List
+	_ func(context.Context, string) (*http.Response, error)                                      = (*DeviceTypeService)(nil).Delete
+	_ func(context.Context, *CreateCallbackInput) (*CreateCallbackOutput, *http.Response, error) = (*DeviceTypeService)(nil).CreateCallback
+	_ func(...QueryParam) (*ListApiUsersOutput, *http.Response, error)                           = (*ApiUserService)(nil).List
+	_ func() (*TileMonarchOutput, *http.Response, error)                                         = (*TileService)(nil).Monarch
+)
+
+func TestDeprecated(t *testing.T) {
+	var got []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.Method+" "+r.URL.Path)
+		fmt.Fprint(w, `{"id":"d1"}`)
+	}))
+	defer server.Close()
+
+	c, _ := NewClient("LOGIN_ID", "PASSWORD", WithBaseURL(server.URL))
+	ctx := context.Background()
+
+	device, err := c.Device.Info("d1")
+	if err != nil || device.ID != "d1" {
+		t.Errorf("Info returned %+v, %v", device, err)
+	}
+	res, err := c.DeviceType.Delete(ctx, "t1")
+	if err != nil || res == nil || res.StatusCode != http.StatusOK {
+		t.Errorf("Delete returned %v, %v", res, err)
+	}
+	input := &CreateCallbackInput{Callbacks: Callbacks{ID: "t1", CallbackSubtype: CallbackSubtypeUplink}}
+	if _, _, err := c.DeviceType.CreateCallback(ctx, input); err != nil {
+		t.Errorf("CreateCallback returned error: %v", err)
+	}
+
+	want := []string{"GET /devices/d1", "DELETE /device-types/t1", "POST /device-types/t1/callbacks"}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("requests are %v, want %v", got, want)
+	}
+}
+
+func TestServiceMethodNames(t *testing.T) {
+	c, _ := NewClient("LOGIN_ID", "PASSWORD")
+	response := reflect.TypeOf((*Response)(nil))
+
+	v := reflect.ValueOf(c).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		svc := v.Field(i)
+		if !v.Type().Field(i).IsExported() || !strings.HasSuffix(svc.Type().String(), "Service") {
+			continue
+		}
+		for j := 0; j < svc.Type().NumMethod(); j++ {
+			m := svc.Type().Method(j)
+			for k := 0; k < m.Type.NumOut(); k++ {
+				if m.Type.Out(k) != response {
+					continue
+				}
+				if !strings.HasSuffix(m.Name, "WithResponse") && !strings.HasSuffix(m.Name, "WithResponseContext") {
+					t.Errorf("%s.%s returns a *Response but is not named FooWithResponse", svc.Type().Elem().Name(), m.Name)
+				}
+			}
+		}
+	}
+}
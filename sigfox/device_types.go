@@ -3,7 +3,8 @@ package sigfox
 import (
 	"context"
 	"fmt"
-	"net/http"
+
+	"github.com/nightswinger/gofox/payload"
 )
 
 type DeviceTypeService service
@@ -14,23 +15,18 @@ type DeviceType struct {
 	Name               string       `json:"name,omitempty"`
 	Description        string       `json:"description,omitempty"`
 	KeepAlive          int64        `json:"keepAlive,omitempty"`
-	PayloadType        int32        `json:"payloadType,omitempty"`
+	PayloadType        PayloadType  `json:"payloadType,omitempty"`
 	AlertEmail         string       `json:"alertEmail,omitempty"`
-	DownlinkMode       int32        `json:"downlinkMode,omitempty"`
+	DownlinkMode       DownlinkMode `json:"downlinkMode,omitempty"`
 	DownlinkDataString string       `json:"downlinkDataString,omitemptys"`
 	Group              Group        `json:"group,omitempty"`
 	Contract           ContractInfo `json:"contract,omitempty"`
-	CreationTime       int64        `json:"creationTime,omitempty"`
+	CreationTime       Timestamp    `json:"creationTime,omitempty"`
 	CreatedBy          string       `json:"createdBy,omitempty"`
-	LastEditionTime    int64        `json:"lastEditionTime,omitempty"`
+	LastEditionTime    Timestamp    `json:"lastEditionTime,omitempty"`
 	LastEditedBy       string       `json:"lastEditedBy,omitempty"`
 }
 
-type ContractInfo struct {
-	ID   string `json:"id,omitempty"`
-	Name string `json:"name,omitempty"`
-}
-
 type ListDeviceTypesOptions struct {
 	Name string `url:"name,omitempty"`
 }
@@ -40,128 +36,156 @@ type ListDeviceTypesOutput struct {
 	Paging Pagination   `json:"paging"`
 }
 
-// List retrieve a list of device types according to visibility permissions and request filters.
-func (s *DeviceTypeService) List(opt *ListDeviceTypesOptions) (*ListDeviceTypesOutput, *http.Response, error) {
-	return s.ListContext(context.Background(), opt)
+// ListWithResponse retrieve a list of device types according to visibility permissions and request filters.
+func (s *DeviceTypeService) ListWithResponse(opt *ListDeviceTypesOptions) (*ListDeviceTypesOutput, *Response, error) {
+	return s.ListWithResponseContext(context.Background(), opt)
 }
 
-// ListContext retrieve a list of device types according to visibility permissions and request filters with context.
-func (s *DeviceTypeService) ListContext(ctx context.Context, opt *ListDeviceTypesOptions) (*ListDeviceTypesOutput, *http.Response, error) {
-	spath := fmt.Sprintf("/device-types")
-	spath, err := addOptions(spath, opt)
+// ListWithResponseContext retrieve a list of device types according to visibility permissions and request filters with context.
+func (s *DeviceTypeService) ListWithResponseContext(ctx context.Context, opt *ListDeviceTypesOptions) (*ListDeviceTypesOutput, *Response, error) {
+	spath, err := addOptions("/device-types", opt)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	req, err := s.client.newRequest(ctx, "GET", spath, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	res, err := s.client.Do(ctx, req)
+	var out ListDeviceTypesOutput
+	res, err := s.client.call(ctx, "GET", spath, nil, &out)
 	if err != nil {
 		return nil, res, err
 	}
 
-	var out ListDeviceTypesOutput
-	if err := decodeBody(res, &out); err != nil {
-		return nil, res, err
-	}
 	return &out, res, nil
 }
 
 type CreateDeviceTypeInput struct {
-	Name               string `json:"name,omitempty"`
-	KeepAlive          int64  `json:"keepAlive,omitempty"`
-	AlertEmail         string `json:"alertEmail,omitempty"`
-	PayloadType        int32  `json:"payloadType,omitempty"`
-	PayloadConfig      string `json:"payloadConfig,omitempty"`
-	DownlinkMode       int32  `json:"downlinkMode,omitempty"`
-	DownlinkDataString string `json:"downlinkDataString,omitempty"`
-	Description        string `json:"description,omitempty"`
-	GroupID            string `json:"groupId,omitempty"`
-	ContractID         string `json:"contractId,omitempty"`
+	Name               string       `json:"name,omitempty"`
+	KeepAlive          int64        `json:"keepAlive,omitempty"`
+	AlertEmail         string       `json:"alertEmail,omitempty"`
+	PayloadType        PayloadType  `json:"payloadType,omitempty"`
+	PayloadConfig      string       `json:"payloadConfig,omitempty"`
+	DownlinkMode       DownlinkMode `json:"downlinkMode,omitempty"`
+	DownlinkDataString string       `json:"downlinkDataString,omitempty"`
+	Description        string       `json:"description,omitempty"`
+	GroupID            string       `json:"groupId,omitempty"`
+	ContractID         string       `json:"contractId,omitempty"`
+}
+
+// Validate checks the values of the input.
+func (input *CreateDeviceTypeInput) Validate() error {
+	return validateDeviceType(input.PayloadType, input.PayloadConfig, input.DownlinkMode)
 }
 
 type CreateDeviceTypeOutput struct {
 	ID string `json:"id,omitempty"`
 }
 
-// Create a new device type.
-func (s *DeviceTypeService) Create(input *CreateDeviceTypeInput) (*CreateDeviceTypeOutput, *http.Response, error) {
-	return s.CreateContext(context.Background(), input)
+// CreateWithResponse create a new device type.
+func (s *DeviceTypeService) CreateWithResponse(input *CreateDeviceTypeInput) (*CreateDeviceTypeOutput, *Response, error) {
+	return s.CreateWithResponseContext(context.Background(), input)
 }
 
-// CreateContext a new device type with context.
-func (s *DeviceTypeService) CreateContext(ctx context.Context, input *CreateDeviceTypeInput) (*CreateDeviceTypeOutput, *http.Response, error) {
-	req, err := s.client.newRequest(ctx, "POST", "/device-types", input)
-	if err != nil {
+// CreateWithResponseContext create a new device type with context.
+func (s *DeviceTypeService) CreateWithResponseContext(ctx context.Context, input *CreateDeviceTypeInput) (*CreateDeviceTypeOutput, *Response, error) {
+	if err := input.Validate(); err != nil {
 		return nil, nil, err
 	}
 
-	res, err := s.client.Do(ctx, req)
-	if err != nil {
-		return nil, res, err
-	}
-
 	var out CreateDeviceTypeOutput
-	if err = decodeBody(res, &out); err != nil {
+	res, err := s.client.call(ctx, "POST", "/device-types", input, &out)
+	if err != nil {
 		return nil, res, err
 	}
 
 	return &out, res, nil
 }
 
-// Info retrieve information about a device type.
-func (s *DeviceTypeService) Info(deviceTypeID string, params ...QueryParam) (*DeviceType, *http.Response, error) {
-	return s.InfoContext(context.Background(), deviceTypeID, params...)
+// InfoWithResponse retrieve information about a device type.
+func (s *DeviceTypeService) InfoWithResponse(deviceTypeID string, params ...QueryParam) (*DeviceType, *Response, error) {
+	return s.InfoWithResponseContext(context.Background(), deviceTypeID, params...)
 }
 
-// InfoContext retrieve information abount a device type with context.
-func (s *DeviceTypeService) InfoContext(ctx context.Context, deviceTypeID string, params ...QueryParam) (*DeviceType, *http.Response, error) {
+// InfoWithResponseContext retrieve information abount a device type with context.
+func (s *DeviceTypeService) InfoWithResponseContext(ctx context.Context, deviceTypeID string, params ...QueryParam) (*DeviceType, *Response, error) {
 	opt := &QueryParams{}
 	for _, param := range params {
 		param(opt)
 	}
 
-	spath := fmt.Sprintf("device-types/%s", deviceTypeID)
+	spath := fmt.Sprintf("/device-types/%s", deviceTypeID)
 	spath, err := addOptions(spath, opt)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	req, err := s.client.newRequest(ctx, "GET", spath, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	res, err := s.client.Do(ctx, req)
+	var out DeviceType
+	res, err := s.client.call(ctx, "GET", spath, nil, &out)
 	if err != nil {
 		return nil, res, err
 	}
 
-	var out DeviceType
-	if err := decodeBody(res, &out); err != nil {
-		return nil, res, err
+	return &out, res, nil
+}
+
+type UpdateDeviceTypeInput struct {
+	Name               string       `json:"name,omitempty"`
+	KeepAlive          int64        `json:"keepAlive,omitempty"`
+	AlertEmail         string       `json:"alertEmail,omitempty"`
+	PayloadType        PayloadType  `json:"payloadType,omitempty"`
+	PayloadConfig      string       `json:"payloadConfig,omitempty"`
+	DownlinkMode       DownlinkMode `json:"downlinkMode,omitempty"`
+	DownlinkDataString string       `json:"downlinkDataString,omitempty"`
+	Description        string       `json:"description,omitempty"`
+	AutomaticRenewal   bool         `json:"automaticRenewal,omitempty"`
+}
+
+// Validate checks the values of the input.
+func (input *UpdateDeviceTypeInput) Validate() error {
+	return validateDeviceType(input.PayloadType, input.PayloadConfig, input.DownlinkMode)
+}
+
+// validateDeviceType checks the payload and downlink settings of a device type.
+// The payload type and downlink mode are optional, and the payload configuration
+// of the custom grammar must parse.
+func validateDeviceType(payloadType PayloadType, payloadConfig string, downlinkMode DownlinkMode) error {
+	var errs fieldErrors
+	if payloadType != 0 && !payloadType.Valid() {
+		errs.add("payloadType", "unknown payload type %v", payloadType)
+	}
+	if payloadType == PayloadTypeCustomGrammar {
+		if _, err := payload.Parse(payloadConfig); err != nil {
+			errs.add("payloadConfig", "%v", err)
+		}
+	}
+	if !downlinkMode.Valid() {
+		errs.add("downlinkMode", "unknown downlink mode %v", downlinkMode)
 	}
+	return errs.err()
+}
 
-	return &out, res, nil
+// UpdateWithResponse update a device type.
+func (s *DeviceTypeService) UpdateWithResponse(deviceTypeID string, input *UpdateDeviceTypeInput) (*Response, error) {
+	return s.UpdateWithResponseContext(context.Background(), deviceTypeID, input)
 }
 
-// Delete a device type.
-func (s *DeviceTypeService) Delete(ctx context.Context, deviceTypeID string) (*http.Response, error) {
-	spath := fmt.Sprintf("/device-types/%s", deviceTypeID)
-	req, err := s.client.newRequest(ctx, "DELETE", spath, nil)
-	if err != nil {
+// UpdateWithResponseContext update a device type with context.
+func (s *DeviceTypeService) UpdateWithResponseContext(ctx context.Context, deviceTypeID string, input *UpdateDeviceTypeInput) (*Response, error) {
+	if err := input.Validate(); err != nil {
 		return nil, err
 	}
 
-	res, err := s.client.Do(ctx, req)
-	if err != nil {
-		return res, err
-	}
+	spath := fmt.Sprintf("/device-types/%s", deviceTypeID)
+	return s.client.call(ctx, "PUT", spath, input, nil)
+}
 
-	return res, nil
+// DeleteWithResponse delete a device type.
+func (s *DeviceTypeService) DeleteWithResponse(deviceTypeID string) (*Response, error) {
+	return s.DeleteWithResponseContext(context.Background(), deviceTypeID)
+}
+
+// DeleteWithResponseContext delete a device type with context.
+func (s *DeviceTypeService) DeleteWithResponseContext(ctx context.Context, deviceTypeID string) (*Response, error) {
+	spath := fmt.Sprintf("/device-types/%s", deviceTypeID)
+	return s.client.call(ctx, "DELETE", spath, nil, nil)
 }
 
 type ListMessagesForDeviceTypeOutput struct {
@@ -169,8 +193,13 @@ type ListMessagesForDeviceTypeOutput struct {
 	Paging Pagination `json:"paging"`
 }
 
-// ListMessages retrieve a list of messages for a given device types with a 3-day history.
-func (s *DeviceTypeService) ListMessages(ctx context.Context, deviceTypeID string, params ...QueryParam) (*ListMessagesForDeviceTypeOutput, *http.Response, error) {
+// ListMessagesWithResponse retrieve a list of messages for a given device types with a 3-day history.
+func (s *DeviceTypeService) ListMessagesWithResponse(deviceTypeID string, params ...QueryParam) (*ListMessagesForDeviceTypeOutput, *Response, error) {
+	return s.ListMessagesWithResponseContext(context.Background(), deviceTypeID, params...)
+}
+
+// ListMessagesWithResponseContext retrieve a list of messages for a given device types with a 3-day history with context.
+func (s *DeviceTypeService) ListMessagesWithResponseContext(ctx context.Context, deviceTypeID string, params ...QueryParam) (*ListMessagesForDeviceTypeOutput, *Response, error) {
 	spath := fmt.Sprintf("/device-types/%s/messages", deviceTypeID)
 
 	opt := &QueryParams{}
@@ -182,18 +211,9 @@ func (s *DeviceTypeService) ListMessages(ctx context.Context, deviceTypeID strin
 		return nil, nil, err
 	}
 
-	req, err := s.client.newRequest(ctx, "GET", spath, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	res, err := s.client.HTTPClient.Do(req)
-	if err != nil {
-		return nil, res, err
-	}
-
 	var out ListMessagesForDeviceTypeOutput
-	if err := decodeBody(res, &out); err != nil {
+	res, err := s.client.call(ctx, "GET", spath, nil, &out)
+	if err != nil {
 		return nil, res, err
 	}
 
@@ -201,10 +221,10 @@ func (s *DeviceTypeService) ListMessages(ctx context.Context, deviceTypeID strin
 }
 
 type ListCallbackErrorsOptions struct {
-	Since  int64 `url:"since,omitempty"`
-	Before int64 `url:"before,omitempty"`
-	Limit  int32 `url:"limit,omitempty"`
-	Offset int32 `url:"offset,omitempty"`
+	Since  Timestamp `url:"since,omitempty"`
+	Before Timestamp `url:"before,omitempty"`
+	Limit  int32     `url:"limit,omitempty"`
+	Offset int32     `url:"offset,omitempty"`
 }
 
 type ListCallbackErrorsOutput struct {
@@ -212,26 +232,22 @@ type ListCallbackErrorsOutput struct {
 	Paging Pagination `json:"paging"`
 }
 
-// ListCallbackErrors retrieve a list of undelivered callback messages for a given device types.
-func (s *DeviceTypeService) ListCallbackErrors(ctx context.Context, deviceTypeID string, opt *ListCallbackErrorsOptions) (*ListCallbackErrorsOutput, *http.Response, error) {
+// ListCallbackErrorsWithResponse retrieve a list of undelivered callback messages for a given device types.
+func (s *DeviceTypeService) ListCallbackErrorsWithResponse(deviceTypeID string, opt *ListCallbackErrorsOptions) (*ListCallbackErrorsOutput, *Response, error) {
+	return s.ListCallbackErrorsWithResponseContext(context.Background(), deviceTypeID, opt)
+}
+
+// ListCallbackErrorsWithResponseContext retrieve a list of undelivered callback messages for a given device types with context.
+func (s *DeviceTypeService) ListCallbackErrorsWithResponseContext(ctx context.Context, deviceTypeID string, opt *ListCallbackErrorsOptions) (*ListCallbackErrorsOutput, *Response, error) {
 	spath := fmt.Sprintf("/device-types/%s/callbacks-not-delivered", deviceTypeID)
 	spath, err := addOptions(spath, opt)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	req, err := s.client.newRequest(ctx, "GET", spath, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	res, err := s.client.Do(ctx, req)
-	if err != nil {
-		return nil, res, err
-	}
-
 	var out ListCallbackErrorsOutput
-	if err := decodeBody(res, &out); err != nil {
+	res, err := s.client.call(ctx, "GET", spath, nil, &out)
+	if err != nil {
 		return nil, res, err
 	}
 
@@ -245,8 +261,8 @@ type ListCallbacksOutput struct {
 type Callbacks struct {
 	ID              string            `json:"id"`
 	Channel         string            `json:"channel"`
-	CallbackType    int32             `json:"callbackType"`
-	CallbackSubtype int32             `json:"callbackSubtype"`
+	CallbackType    CallbackType      `json:"callbackType"`
+	CallbackSubtype CallbackSubtype   `json:"callbackSubtype"`
 	PayloadConfig   string            `json:"payloadConfig,omitempty"`
 	Enabled         bool              `json:"enabled"`
 	SendDuplicate   bool              `json:"sendDuplicate"`
@@ -263,22 +279,18 @@ type Callbacks struct {
 	Message         string            `json:"message,omitempty"`
 }
 
-// ListCallbacks retrieve a list of callbacks for a given device type according to visibility permissions and request filters.
-func (s *DeviceTypeService) ListCallbacks(ctx context.Context, deviceTypeID string) (*ListCallbacksOutput, *http.Response, error) {
-	spath := fmt.Sprintf("/device-types/%s/callbacks", deviceTypeID)
-
-	req, err := s.client.newRequest(ctx, "GET", spath, nil)
-	if err != nil {
-		return nil, nil, err
-	}
+// ListCallbacksWithResponse retrieve a list of callbacks for a given device type according to visibility permissions and request filters.
+func (s *DeviceTypeService) ListCallbacksWithResponse(deviceTypeID string) (*ListCallbacksOutput, *Response, error) {
+	return s.ListCallbacksWithResponseContext(context.Background(), deviceTypeID)
+}
 
-	res, err := s.client.Do(ctx, req)
-	if err != nil {
-		return nil, res, err
-	}
+// ListCallbacksWithResponseContext retrieve a list of callbacks for a given device type according to visibility permissions and request filters with context.
+func (s *DeviceTypeService) ListCallbacksWithResponseContext(ctx context.Context, deviceTypeID string) (*ListCallbacksOutput, *Response, error) {
+	spath := fmt.Sprintf("/device-types/%s/callbacks", deviceTypeID)
 
 	var out ListCallbacksOutput
-	if err := decodeBody(res, &out); err != nil {
+	res, err := s.client.call(ctx, "GET", spath, nil, &out)
+	if err != nil {
 		return nil, res, err
 	}
 
@@ -290,25 +302,56 @@ type CreateCallbackInput struct {
 	ContentType string `json:"contentType"`
 }
 
+// Validate checks the values of the input.
+func (input *CreateCallbackInput) Validate() error {
+	return input.Callbacks.validate(true)
+}
+
+// validate checks the type of the callback and the target of its channel.
+// The subtype is checked against the type when checkSubtype is set.
+func (cb *Callbacks) validate(checkSubtype bool) error {
+	var errs fieldErrors
+	if !cb.CallbackType.Valid() {
+		errs.add("callbackType", "unknown callback type %v", cb.CallbackType)
+	} else if checkSubtype && !cb.CallbackSubtype.ValidFor(cb.CallbackType) {
+		errs.add("callbackSubtype", "subtype %v is not valid for %v callbacks", cb.CallbackSubtype, cb.CallbackType)
+	}
+	switch cb.Channel {
+	case "URL", "BATCH_URL":
+		if cb.URL == "" {
+			errs.add("url", "required by the %s channel", cb.Channel)
+		}
+	case "EMAIL":
+		if cb.Recipient == "" {
+			errs.add("recipient", "required by the %s channel", cb.Channel)
+		}
+	case "":
+	default:
+		errs.add("channel", "unknown channel %q", cb.Channel)
+	}
+	return errs.err()
+}
+
 type CreateCallbackOutput struct {
 	ID string `json:"id,omitempty"`
 }
 
-// CreateCallback create a new callback for a given device type.
-func (s *DeviceTypeService) CreateCallback(ctx context.Context, input *CreateCallbackInput) (*CreateCallbackOutput, *http.Response, error) {
-	spath := fmt.Sprintf("/device-types/%s/callbacks", input.ID)
-	req, err := s.client.newRequest(ctx, "POST", spath, input)
-	if err != nil {
+// CreateCallbackWithResponse create a new callback for a given device type.
+func (s *DeviceTypeService) CreateCallbackWithResponse(deviceTypeID string, input *CreateCallbackInput) (*CreateCallbackOutput, *Response, error) {
+	return s.CreateCallbackWithResponseContext(context.Background(), deviceTypeID, input)
+}
+
+// CreateCallbackWithResponseContext create a new callback for a given device type with context.
+func (s *DeviceTypeService) CreateCallbackWithResponseContext(ctx context.Context, deviceTypeID string, input *CreateCallbackInput) (*CreateCallbackOutput, *Response, error) {
+	if err := input.Validate(); err != nil {
 		return nil, nil, err
 	}
 
-	res, err := s.client.Do(ctx, req)
-	if err != nil {
-		return nil, res, err
-	}
+	spath := fmt.Sprintf("/device-types/%s/callbacks", deviceTypeID)
 
 	var out CreateCallbackOutput
-	if err = decodeBody(res, &out); err != nil {
+	res, err := s.client.call(ctx, "POST", spath, input, &out)
+	if err != nil {
 		return nil, res, err
 	}
 
@@ -320,18 +363,77 @@ type UpdateCallbackInput struct {
 	ContentType string `json:"contentType"`
 }
 
-// UpdateCallback update a callback for a given device type.
-func (s *DeviceTypeService) UpdateCallback(ctx context.Context, deviceTypeID, callbackID string, input *UpdateCallbackInput) (*http.Response, error) {
-	spath := fmt.Sprintf("/device-types/%s/callbacks/%s", deviceTypeID, callbackID)
-	req, err := s.client.newRequest(ctx, "PUT", spath, input)
-	if err != nil {
+// Validate checks the values of the input. As the type and subtype of a callback
+// cannot be updated, they may be left unset and the subtype is not checked.
+func (input *UpdateCallbackInput) Validate() error {
+	return input.Callbacks.validate(false)
+}
+
+// UpdateCallbackWithResponse update a callback for a given device type.
+func (s *DeviceTypeService) UpdateCallbackWithResponse(deviceTypeID, callbackID string, input *UpdateCallbackInput) (*Response, error) {
+	return s.UpdateCallbackWithResponseContext(context.Background(), deviceTypeID, callbackID, input)
+}
+
+// UpdateCallbackWithResponseContext update a callback for a given device type with context.
+func (s *DeviceTypeService) UpdateCallbackWithResponseContext(ctx context.Context, deviceTypeID, callbackID string, input *UpdateCallbackInput) (*Response, error) {
+	if err := input.Validate(); err != nil {
 		return nil, err
 	}
 
-	res, err := s.client.Do(ctx, req)
+	spath := fmt.Sprintf("/device-types/%s/callbacks/%s", deviceTypeID, callbackID)
+	return s.client.call(ctx, "PUT", spath, input, nil)
+}
+
+// DeleteCallbackWithResponse delete a callback for a given device type.
+func (s *DeviceTypeService) DeleteCallbackWithResponse(deviceTypeID, callbackID string) (*Response, error) {
+	return s.DeleteCallbackWithResponseContext(context.Background(), deviceTypeID, callbackID)
+}
+
+// DeleteCallbackWithResponseContext delete a callback for a given device type with context.
+func (s *DeviceTypeService) DeleteCallbackWithResponseContext(ctx context.Context, deviceTypeID, callbackID string) (*Response, error) {
+	spath := fmt.Sprintf("/device-types/%s/callbacks/%s", deviceTypeID, callbackID)
+	return s.client.call(ctx, "DELETE", spath, nil, nil)
+}
+
+type enableCallbackOptions struct {
+	Enabled bool `url:"enabled"`
+}
+
+// EnableCallbackWithResponse enable or disable a callback for a given device type.
+func (s *DeviceTypeService) EnableCallbackWithResponse(deviceTypeID, callbackID string, enabled bool) (*Response, error) {
+	return s.EnableCallbackWithResponseContext(context.Background(), deviceTypeID, callbackID, enabled)
+}
+
+// EnableCallbackWithResponseContext enable or disable a callback for a given device type with context.
+func (s *DeviceTypeService) EnableCallbackWithResponseContext(ctx context.Context, deviceTypeID, callbackID string, enabled bool) (*Response, error) {
+	spath := fmt.Sprintf("/device-types/%s/callbacks/%s/enable", deviceTypeID, callbackID)
+	spath, err := addOptions(spath, &enableCallbackOptions{Enabled: enabled})
 	if err != nil {
-		return res, err
+		return nil, err
 	}
 
-	return res, nil
+	return s.client.call(ctx, "PUT", spath, nil, nil)
+}
+
+// AcknowledgeCallbackErrorsWithResponse acknowledge the errors of a dead callback for a given device type,
+// so that it is no longer flagged as dead.
+func (s *DeviceTypeService) AcknowledgeCallbackErrorsWithResponse(deviceTypeID, callbackID string) (*Response, error) {
+	return s.AcknowledgeCallbackErrorsWithResponseContext(context.Background(), deviceTypeID, callbackID)
+}
+
+// AcknowledgeCallbackErrorsWithResponseContext acknowledge the errors of a dead callback for a given device type with context.
+func (s *DeviceTypeService) AcknowledgeCallbackErrorsWithResponseContext(ctx context.Context, deviceTypeID, callbackID string) (*Response, error) {
+	spath := fmt.Sprintf("/device-types/%s/callbacks/%s/callbacks-not-delivered", deviceTypeID, callbackID)
+	return s.client.call(ctx, "PUT", spath, nil, nil)
+}
+
+// SelectDownlinkCallbackWithResponse select the callback used to get the downlink data of a given device type.
+func (s *DeviceTypeService) SelectDownlinkCallbackWithResponse(deviceTypeID, callbackID string) (*Response, error) {
+	return s.SelectDownlinkCallbackWithResponseContext(context.Background(), deviceTypeID, callbackID)
+}
+
+// SelectDownlinkCallbackWithResponseContext select the callback used to get the downlink data of a given device type with context.
+func (s *DeviceTypeService) SelectDownlinkCallbackWithResponseContext(ctx context.Context, deviceTypeID, callbackID string) (*Response, error) {
+	spath := fmt.Sprintf("/device-types/%s/callbacks/%s/downlink", deviceTypeID, callbackID)
+	return s.client.call(ctx, "PUT", spath, nil, nil)
 }
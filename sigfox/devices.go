@@ -2,33 +2,35 @@ package sigfox
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"time"
 )
 
 type DeviceService service
 
 type Device struct {
-	ID                  string `json:"id,omitempty"`
-	Name                string `json:"name,omitempty"`
-	Prototype           bool   `json:"prototype,omitempty"`
-	PAC                 string `json:"pac,omitempty"`
-	SequenceNumber      int32  `json:"sequenceNumber,omitempty"`
-	TrashSequenceNumber int32  `json:"trashSequenceNumber,omitempty"`
-	LastCom             int64  `json:"lastCom,omitempty"`
-	Lqi                 int32  `json:"lqi,omitempty"`
-	AverageSnr          string `json:"averageSnr,omitempty"`
-	AverageRssi         string `json:"averageRssi,omitempty"`
-	ActivationTime      int64  `json:"activationTime,omitempty"`
-	CreationTime        int64  `json:"creationTime,omitempty"`
-	State               int32  `json:"state,omitempty"`
-	ComState            int32  `json:"comState,omitempty"`
+	ID                  string      `json:"id,omitempty"`
+	Name                string      `json:"name,omitempty"`
+	Prototype           bool        `json:"prototype,omitempty"`
+	PAC                 string      `json:"pac,omitempty"`
+	SequenceNumber      int32       `json:"sequenceNumber,omitempty"`
+	TrashSequenceNumber int32       `json:"trashSequenceNumber,omitempty"`
+	LastCom             Timestamp   `json:"lastCom,omitempty"`
+	Lqi                 int32       `json:"lqi,omitempty"`
+	AverageSnr          float64     `json:"averageSnr,omitempty"`
+	AverageRssi         float64     `json:"averageRssi,omitempty"`
+	ActivationTime      Timestamp   `json:"activationTime,omitempty"`
+	CreationTime        Timestamp   `json:"creationTime,omitempty"`
+	State               DeviceState `json:"state,omitempty"`
+	ComState            ComState    `json:"comState,omitempty"`
 	//Token
-	UnsubscriptionTime     int64  `json:"unsubscriptionTime,omitempty"`
-	CreatedBy              string `json:"createdBy,omitempty"`
-	LastEditionTime        int64  `json:"lastEditionTime,omitempty"`
-	AutomaticRenewal       bool   `json:"automaticRenewal,omitempty"`
-	AutomaticRenewalStatus int32  `json:"automaticRenewalStatus,omitempty"`
-	Activable              bool   `json:"activable,omitempty"`
+	UnsubscriptionTime     Timestamp              `json:"unsubscriptionTime,omitempty"`
+	CreatedBy              string                 `json:"createdBy,omitempty"`
+	LastEditionTime        Timestamp              `json:"lastEditionTime,omitempty"`
+	AutomaticRenewal       bool                   `json:"automaticRenewal,omitempty"`
+	AutomaticRenewalStatus AutomaticRenewalStatus `json:"automaticRenewalStatus,omitempty"`
+	Activable              bool                   `json:"activable,omitempty"`
 }
 
 type DeviceListOptions struct {
@@ -51,33 +53,25 @@ type ListDevices struct {
 	Paging Pagination `json:"paging"`
 }
 
-func (s *DeviceService) List(opt *DeviceListOptions) (*ListDevices, error) {
-	return s.ListContext(context.Background(), opt)
+// ListWithResponse retrieve a list of devices according to visibility permissions and request filters.
+func (s *DeviceService) ListWithResponse(opt *DeviceListOptions) (*ListDevices, *Response, error) {
+	return s.ListWithResponseContext(context.Background(), opt)
 }
 
-func (s *DeviceService) ListContext(ctx context.Context, opt *DeviceListOptions) (*ListDevices, error) {
-	spath := fmt.Sprintf("/devices")
-	spath, err := addOptions(spath, opt)
+// ListWithResponseContext retrieve a list of devices according to visibility permissions and request filters with context.
+func (s *DeviceService) ListWithResponseContext(ctx context.Context, opt *DeviceListOptions) (*ListDevices, *Response, error) {
+	spath, err := addOptions("/devices", opt)
 	if err != nil {
-		return nil, err
-	}
-
-	req, err := s.client.newRequest(ctx, "GET", spath, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	res, err := s.client.HTTPClient.Do(req)
-	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	var listDevices ListDevices
-	if err := decodeBody(res, &listDevices); err != nil {
-		return nil, err
+	res, err := s.client.call(ctx, "GET", spath, nil, &listDevices)
+	if err != nil {
+		return nil, res, err
 	}
 
-	return &listDevices, nil
+	return &listDevices, res, nil
 }
 
 type CreateDeviceBody struct {
@@ -97,50 +91,38 @@ type CreateDeviceOutput struct {
 	ID string `json:"id"`
 }
 
-func (s *DeviceService) Create(body *CreateDeviceBody) (*CreateDeviceOutput, error) {
-	return s.CreateContext(context.Background(), body)
+// CreateWithResponse create a new device.
+func (s *DeviceService) CreateWithResponse(body *CreateDeviceBody) (*CreateDeviceOutput, *Response, error) {
+	return s.CreateWithResponseContext(context.Background(), body)
 }
 
-func (s *DeviceService) CreateContext(ctx context.Context, body *CreateDeviceBody) (*CreateDeviceOutput, error) {
-	req, err := s.client.newRequest(ctx, "POST", "/devices", body)
-	if err != nil {
-		return nil, err
-	}
-
-	res, err := s.client.HTTPClient.Do(req)
+// CreateWithResponseContext create a new device with context.
+func (s *DeviceService) CreateWithResponseContext(ctx context.Context, body *CreateDeviceBody) (*CreateDeviceOutput, *Response, error) {
+	var output CreateDeviceOutput
+	res, err := s.client.call(ctx, "POST", "/devices", body, &output)
 	if err != nil {
-		return nil, err
+		return nil, res, err
 	}
 
-	var output CreateDeviceOutput
-	if err := decodeBody(res, &output); err != nil {
-		return nil, err
-	}
-	return &output, nil
+	return &output, res, nil
 }
 
-func (s *DeviceService) Info(deviceID string) (*Device, error) {
-	return s.InfoContext(context.Background(), deviceID)
+// InfoWithResponse retrieve information about a device.
+func (s *DeviceService) InfoWithResponse(deviceID string) (*Device, *Response, error) {
+	return s.InfoWithResponseContext(context.Background(), deviceID)
 }
 
-func (s *DeviceService) InfoContext(ctx context.Context, deviceID string) (*Device, error) {
+// InfoWithResponseContext retrieve information about a device with context.
+func (s *DeviceService) InfoWithResponseContext(ctx context.Context, deviceID string) (*Device, *Response, error) {
 	spath := fmt.Sprintf("/devices/%s", deviceID)
-	req, err := s.client.newRequest(ctx, "GET", spath, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	res, err := s.client.Do(ctx, req)
-	if err != nil {
-		return nil, err
-	}
 
 	var device Device
-	if err := decodeBody(res, &device); err != nil {
-		return nil, err
+	res, err := s.client.call(ctx, "GET", spath, nil, &device)
+	if err != nil {
+		return nil, res, err
 	}
 
-	return &device, nil
+	return &device, res, nil
 }
 
 type UpdateDeviceBody struct {
@@ -153,31 +135,22 @@ type UpdateDeviceBody struct {
 	Activable             bool    `json:"activable,omitempty"`
 }
 
-func (s *DeviceService) Update(deviceID string, body *UpdateDeviceBody) error {
-	return s.UpdateContext(context.Background(), deviceID, body)
+// UpdateWithResponse update a device.
+func (s *DeviceService) UpdateWithResponse(deviceID string, body *UpdateDeviceBody) (*Response, error) {
+	return s.UpdateWithResponseContext(context.Background(), deviceID, body)
 }
 
-func (s *DeviceService) UpdateContext(ctx context.Context, deviceID string, body *UpdateDeviceBody) error {
+// UpdateWithResponseContext update a device with context.
+func (s *DeviceService) UpdateWithResponseContext(ctx context.Context, deviceID string, body *UpdateDeviceBody) (*Response, error) {
 	spath := fmt.Sprintf("/devices/%s", deviceID)
-
-	req, err := s.client.newRequest(ctx, "PUT", spath, body)
-	if err != nil {
-		return err
-	}
-
-	_, err = s.client.Do(ctx, req)
-	if err != nil {
-		return err
-	}
-
-	return nil
+	return s.client.call(ctx, "PUT", spath, body, nil)
 }
 
 type UndeliveredCallbacksOptions struct {
-	Since  int64 `url:"since,omitempty"`
-	Before int64 `url:"before,omitempty"`
-	Limit  int32 `url:"limit,omitempty"`
-	Offset int32 `url:"offset,omitempty"`
+	Since  Timestamp `url:"since,omitempty"`
+	Before Timestamp `url:"before,omitempty"`
+	Limit  int32     `url:"limit,omitempty"`
+	Offset int32     `url:"offset,omitempty"`
 }
 
 type UndeliveredCallbacks struct {
@@ -189,7 +162,7 @@ type Hosts struct {
 	Device     string          `json:"device"`
 	DeviceURL  string          `json:"deviceUrl"`
 	DeviceType string          `json:"deviceType"`
-	Time       int64           `json:"time"`
+	Time       Timestamp       `json:"time"`
 	Data       string          `json:"data"`
 	Snr        string          `json:"snr"`
 	Status     string          `json:"status"`
@@ -209,60 +182,45 @@ type CallbackMedium struct {
 	Error       string `json:"error"`
 }
 
-func (s *DeviceService) ListUndeliveredCallbacks(deviceID string, opt *UndeliveredCallbacksOptions) (*UndeliveredCallbacks, error) {
-	return s.ListUndeliveredCallbacksContext(context.Background(), deviceID, opt)
+// ListUndeliveredCallbacksWithResponse retrieve a list of undelivered callback messages for a given device.
+func (s *DeviceService) ListUndeliveredCallbacksWithResponse(deviceID string, opt *UndeliveredCallbacksOptions) (*UndeliveredCallbacks, *Response, error) {
+	return s.ListUndeliveredCallbacksWithResponseContext(context.Background(), deviceID, opt)
 }
 
-func (s *DeviceService) ListUndeliveredCallbacksContext(ctx context.Context, deviceID string, opt *UndeliveredCallbacksOptions) (*UndeliveredCallbacks, error) {
+// ListUndeliveredCallbacksWithResponseContext retrieve a list of undelivered callback messages for a given device with context.
+func (s *DeviceService) ListUndeliveredCallbacksWithResponseContext(ctx context.Context, deviceID string, opt *UndeliveredCallbacksOptions) (*UndeliveredCallbacks, *Response, error) {
 	spath := fmt.Sprintf("/devices/%s/callbacks-not-delivered", deviceID)
 	spath, err := addOptions(spath, opt)
 	if err != nil {
-		return nil, err
-	}
-
-	req, err := s.client.newRequest(ctx, "GET", spath, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	res, err := s.client.Do(ctx, req)
-	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	var listUndelivered UndeliveredCallbacks
-	if err := decodeBody(res, &listUndelivered); err != nil {
-		return nil, err
+	res, err := s.client.call(ctx, "GET", spath, nil, &listUndelivered)
+	if err != nil {
+		return nil, res, err
 	}
 
-	return &listUndelivered, nil
+	return &listUndelivered, res, nil
 }
 
-func (s *DeviceService) DisengageSequenceNumber(deviceID string) error {
-	return s.DisengageSequenceNumberContext(context.Background(), deviceID)
+// DisengageSequenceNumberWithResponse disengage sequence number check for next message of a device.
+func (s *DeviceService) DisengageSequenceNumberWithResponse(deviceID string) (*Response, error) {
+	return s.DisengageSequenceNumberWithResponseContext(context.Background(), deviceID)
 }
 
-func (s *DeviceService) DisengageSequenceNumberContext(ctx context.Context, deviceID string) error {
+// DisengageSequenceNumberWithResponseContext disengage sequence number check for next message of a device with context.
+func (s *DeviceService) DisengageSequenceNumberWithResponseContext(ctx context.Context, deviceID string) (*Response, error) {
 	spath := fmt.Sprintf("/devices/%s/disengage", deviceID)
-
-	req, err := s.client.newRequest(ctx, "POST", spath, nil)
-	if err != nil {
-		return err
-	}
-	_, err = s.client.Do(ctx, req)
-	if err != nil {
-		return err
-	}
-
-	return nil
+	return s.client.call(ctx, "POST", spath, nil, nil)
 }
 
 type DeviceMessagesOptions struct {
-	Fields string `url:"fields,omitempty"`
-	Since  int64  `url:"since,omitempty"`
-	Before int64  `url:"before,omitempty"`
-	Limit  int    `url:"limit,omitempty"`
-	Offset int32  `url:"offset,omitempty"`
+	Fields string    `url:"fields,omitempty"`
+	Since  Timestamp `url:"since,omitempty"`
+	Before Timestamp `url:"before,omitempty"`
+	Limit  int       `url:"limit,omitempty"`
+	Offset int32     `url:"offset,omitempty"`
 }
 
 type DeviceMessages struct {
@@ -271,29 +229,75 @@ type DeviceMessages struct {
 }
 
 type Message struct {
-	Device       Device `json:"device,omitempty"`
-	Time         int64  `json:"time,omitempty"`
-	Data         string `json:"data,omitempty"`
-	AckRequired  bool   `json:"ackRequired,omitempty"`
-	Lqi          int32  `json:"lqi,omitempty"`
-	LqiRepeaters int32  `json:"lqiRepeaters,omitempty"`
-	SeqNumber    int32  `json:"seqNumber,omitempty"`
-	NbFrames     int32  `json:"nbFrames,omitempty"`
-	//ComputedLocation
-	Rinfos []Rinfo `json:"rinfos,omitempty"`
-	//DownlinkAnserStatus
+	Device       Device    `json:"device,omitempty"`
+	Time         Timestamp `json:"time,omitempty"`
+	Data         string    `json:"data,omitempty"`
+	AckRequired  bool      `json:"ackRequired,omitempty"`
+	Lqi          int32     `json:"lqi,omitempty"`
+	LqiRepeaters int32     `json:"lqiRepeaters,omitempty"`
+	SeqNumber    int32     `json:"seqNumber,omitempty"`
+	NbFrames     int32     `json:"nbFrames,omitempty"`
+	// ComputedLocation holds the locations computed by the geolocation services, such as Atlas.
+	ComputedLocation ComputedLocations `json:"computedLocation,omitempty"`
+	Rinfos           []Rinfo           `json:"rinfos,omitempty"`
+	// DownlinkAnswerStatus reports the downlink sent in answer to the message, if any.
+	DownlinkAnswerStatus *DownlinkAnswerStatus `json:"downlinkAnswerStatus,omitempty"`
+}
+
+// ComputedLocation is a location of a device computed from a message.
+type ComputedLocation struct {
+	Lat float64 `json:"lat"`
+	Lng float64 `json:"lng"`
+	// Radius is the accuracy of the location in meters.
+	Radius float64 `json:"radius"`
+	// Source is the service which computed the location, e.g. 2 for Atlas.
+	Source int32 `json:"source"`
+	// Status tells whether the location could be computed, 1 meaning that it was.
+	Status   int32    `json:"status"`
+	PlaceIds []string `json:"placeIds,omitempty"`
+}
+
+// ComputedLocations decodes the computed locations of a message,
+// given either as a list or as a single object.
+type ComputedLocations []ComputedLocation
+
+func (l *ComputedLocations) UnmarshalJSON(data []byte) error {
+	var list []ComputedLocation
+	if err := json.Unmarshal(data, &list); err == nil {
+		*l = list
+		return nil
+	}
+
+	var loc ComputedLocation
+	if err := json.Unmarshal(data, &loc); err != nil {
+		return err
+	}
+	*l = ComputedLocations{loc}
+	return nil
+}
+
+// DownlinkAnswerStatus is the status of the downlink sent in answer to a message.
+type DownlinkAnswerStatus struct {
+	// BaseStation is the base station which sent the downlink.
+	BaseStation MinBaseStation `json:"baseStation"`
+	// PlannedPower is the planned transmission power in dBm.
+	PlannedPower float64 `json:"plannedPower"`
+	// Data is the downlink payload in hexadecimal.
+	Data     string `json:"data"`
+	Operator string `json:"operator"`
+	Country  string `json:"country"`
 }
 
 type Rinfo struct {
 	BaseStation   MinBaseStation `json:"baseStation,omitempty"`
-	Rssi          string         `json:"rssi,omitempty"`
-	RssiRepeaters string         `json:"rssiRepeaters"`
-	Lat           string         `json:"lat,omitempty"`
-	Lng           string         `json:"lng,omitempty"`
-	Snr           string         `json:"snr,omitempty"`
-	SnrRepeaters  string         `json:"snrRepeaters,omitempty"`
+	Rssi          float64        `json:"rssi,omitempty"`
+	RssiRepeaters float64        `json:"rssiRepeaters,omitempty"`
+	Lat           float64        `json:"lat,omitempty"`
+	Lng           float64        `json:"lng,omitempty"`
+	Snr           float64        `json:"snr,omitempty"`
+	SnrRepeaters  float64        `json:"snrRepeaters,omitempty"`
 	Freq          float64        `json:"freq,omitempty"`
-	FreqRepeaters float64        `json:"freqReaters"`
+	FreqRepeaters float64        `json:"freqRepeaters,omitempty"`
 	Rep           int32          `json:"rep,omitempty"`
 	CbStatus      []CbStatus     `json:"cbStatus,omitempty"`
 }
@@ -304,17 +308,19 @@ type MinBaseStation struct {
 }
 
 type CbStatus struct {
-	Status int32  `json:"status,omitempty"`
-	Info   string `json:"string,omitempty"`
-	CbDef  string `json:"cbDef,omitempty"`
-	Time   int64  `json:"time,omitempty"`
+	Status int32     `json:"status,omitempty"`
+	Info   string    `json:"string,omitempty"`
+	CbDef  string    `json:"cbDef,omitempty"`
+	Time   Timestamp `json:"time,omitempty"`
 }
 
-func (s *DeviceService) Messages(deviceID string, params ...QueryParam) (*DeviceMessages, error) {
-	return s.MessagesContext(context.Background(), deviceID, params...)
+// MessagesWithResponse retrieve a list of messages for a given device with a 3-day history.
+func (s *DeviceService) MessagesWithResponse(deviceID string, params ...QueryParam) (*DeviceMessages, *Response, error) {
+	return s.MessagesWithResponseContext(context.Background(), deviceID, params...)
 }
 
-func (s *DeviceService) MessagesContext(ctx context.Context, deviceID string, params ...QueryParam) (*DeviceMessages, error) {
+// MessagesWithResponseContext retrieve a list of messages for a given device with a 3-day history with context.
+func (s *DeviceService) MessagesWithResponseContext(ctx context.Context, deviceID string, params ...QueryParam) (*DeviceMessages, *Response, error) {
 	spath := fmt.Sprintf("/devices/%s/messages", deviceID)
 
 	opt := &QueryParams{}
@@ -323,25 +329,64 @@ func (s *DeviceService) MessagesContext(ctx context.Context, deviceID string, pa
 	}
 	spath, err := addOptions(spath, opt)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	req, err := s.client.newRequest(ctx, "GET", spath, nil)
+	var messages DeviceMessages
+	res, err := s.client.call(ctx, "GET", spath, nil, &messages)
 	if err != nil {
-		return nil, err
+		return nil, res, err
 	}
 
-	res, err := s.client.Do(ctx, req)
+	return &messages, res, nil
+}
+
+// DeviceLocations is a page of the locations of a device.
+type DeviceLocations struct {
+	Data   []DeviceLocation `json:"data,omitempty"`
+	Paging Pagination       `json:"paging,omitempty"`
+}
+
+// DeviceLocation is a location of a device computed by the Sigfox backend.
+type DeviceLocation struct {
+	Time Timestamp `json:"time"`
+	// Valid tells whether the location could be computed.
+	Valid bool    `json:"valid"`
+	Lat   float64 `json:"lat"`
+	Lng   float64 `json:"lng"`
+	// Radius is the accuracy of the location in meters.
+	Radius float64 `json:"radius"`
+	// Source is the service which computed the location, e.g. 2 for Atlas.
+	Source   int32    `json:"source"`
+	PlaceIds []string `json:"placeIds,omitempty"`
+}
+
+// LocationsWithResponse retrieve a list of the locations of a device, most recent first.
+func (s *DeviceService) LocationsWithResponse(deviceID string, params ...QueryParam) (*DeviceLocations, *Response, error) {
+	return s.LocationsWithResponseContext(context.Background(), deviceID, params...)
+}
+
+// LocationsWithResponseContext retrieve a list of the locations of a device, most recent first with context.
+// The Since and Before params restrict the locations to a time range.
+func (s *DeviceService) LocationsWithResponseContext(ctx context.Context, deviceID string, params ...QueryParam) (*DeviceLocations, *Response, error) {
+	spath := fmt.Sprintf("/devices/%s/locations", deviceID)
+
+	opt := &QueryParams{}
+	for _, param := range params {
+		param(opt)
+	}
+	spath, err := addOptions(spath, opt)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	var messages DeviceMessages
-	if err := decodeBody(res, &messages); err != nil {
-		return nil, err
+	var locations DeviceLocations
+	res, err := s.client.call(ctx, "GET", spath, nil, &locations)
+	if err != nil {
+		return nil, res, err
 	}
 
-	return &messages, nil
+	return &locations, res, nil
 }
 
 type DeviceMetric struct {
@@ -350,29 +395,92 @@ type DeviceMetric struct {
 	LastMonth int32 `json:"lastMonth"`
 }
 
+// MetricWithResponse retrieve the number of messages for a given device.
 // Perhaps metric is updated at 1:00AM UTC
-func (s *DeviceService) Metric(deviceID string) (*DeviceMetric, error) {
-	return s.MetricContext(context.Background(), deviceID)
+func (s *DeviceService) MetricWithResponse(deviceID string) (*DeviceMetric, *Response, error) {
+	return s.MetricWithResponseContext(context.Background(), deviceID)
 }
 
-func (s *DeviceService) MetricContext(ctx context.Context, deviceID string) (*DeviceMetric, error) {
+// MetricWithResponseContext retrieve the number of messages for a given device with context.
+func (s *DeviceService) MetricWithResponseContext(ctx context.Context, deviceID string) (*DeviceMetric, *Response, error) {
 	spath := fmt.Sprintf("/devices/%s/messages/metric", deviceID)
 
-	req, err := s.client.newRequest(ctx, "GET", spath, nil)
-	if err != nil {
-		return nil, err
-	}
-	res, err := s.client.Do(ctx, req)
+	var deviceMetric DeviceMetric
+	res, err := s.client.call(ctx, "GET", spath, nil, &deviceMetric)
 	if err != nil {
-		return nil, err
+		return nil, res, err
 	}
 
-	var deviceMetric DeviceMetric
-	if err := decodeBody(res, &deviceMetric); err != nil {
-		return nil, err
+	return &deviceMetric, res, nil
+}
+
+// DeviceConsumption holds the numbers of messages of a device per day.
+type DeviceConsumption struct {
+	Consumption Consumption `json:"consumption"`
+}
+
+type Consumption struct {
+	ID string `json:"id"`
+	// Consumptions holds a count per day of the period, the first day first.
+	Consumptions []DayConsumption `json:"consumptions"`
+}
+
+// DayConsumption is the number of messages of a device during a day.
+type DayConsumption struct {
+	FrameCount         int32                `json:"frameCount"`
+	DownlinkFrameCount int32                `json:"downlinkFrameCount"`
+	RoamingDetails     []RoamingConsumption `json:"roamingDetails,omitempty"`
+}
+
+// RoamingConsumption is the number of messages of a device through a roaming operator.
+type RoamingConsumption struct {
+	TerritoryOperator  string `json:"territoryOperator"`
+	FrameCount         int32  `json:"frameCount"`
+	DownlinkFrameCount int32  `json:"downlinkFrameCount"`
+}
+
+// ConsumptionWithResponse retrieve the number of messages per day of a given device.
+func (s *DeviceService) ConsumptionWithResponse(deviceID string) (*DeviceConsumption, *Response, error) {
+	return s.ConsumptionWithResponseContext(context.Background(), deviceID)
+}
+
+// ConsumptionWithResponseContext retrieve the number of messages per day of a given device with context.
+func (s *DeviceService) ConsumptionWithResponseContext(ctx context.Context, deviceID string) (*DeviceConsumption, *Response, error) {
+	spath := fmt.Sprintf("/devices/%s/consumption", deviceID)
+	return s.consumption(ctx, spath)
+}
+
+// YearConsumptionWithResponse retrieve the number of messages per day of a given device during a year.
+func (s *DeviceService) YearConsumptionWithResponse(deviceID string, year int) (*DeviceConsumption, *Response, error) {
+	return s.YearConsumptionWithResponseContext(context.Background(), deviceID, year)
+}
+
+// YearConsumptionWithResponseContext retrieve the number of messages per day of a given device during a year with context.
+func (s *DeviceService) YearConsumptionWithResponseContext(ctx context.Context, deviceID string, year int) (*DeviceConsumption, *Response, error) {
+	spath := fmt.Sprintf("/devices/%s/consumptions/%d", deviceID, year)
+	return s.consumption(ctx, spath)
+}
+
+// MonthConsumptionWithResponse retrieve the number of messages per day of a given device during a month.
+// The month is numbered from 1 to 12.
+func (s *DeviceService) MonthConsumptionWithResponse(deviceID string, year int, month time.Month) (*DeviceConsumption, *Response, error) {
+	return s.MonthConsumptionWithResponseContext(context.Background(), deviceID, year, month)
+}
+
+// MonthConsumptionWithResponseContext retrieve the number of messages per day of a given device during a month with context.
+func (s *DeviceService) MonthConsumptionWithResponseContext(ctx context.Context, deviceID string, year int, month time.Month) (*DeviceConsumption, *Response, error) {
+	spath := fmt.Sprintf("/devices/%s/consumptions/%d/%d", deviceID, year, int(month))
+	return s.consumption(ctx, spath)
+}
+
+func (s *DeviceService) consumption(ctx context.Context, spath string) (*DeviceConsumption, *Response, error) {
+	var consumption DeviceConsumption
+	res, err := s.client.call(ctx, "GET", spath, nil, &consumption)
+	if err != nil {
+		return nil, res, err
 	}
 
-	return &deviceMetric, nil
+	return &consumption, res, nil
 }
 
 type CreateMultipleDevicesBody struct {
@@ -398,24 +506,220 @@ type CreateMultipleDevicesOutput struct {
 	JobID string `json:"jobId,omitempty"`
 }
 
-func (s *DeviceService) CreateMultipleWithAsync(body *CreateMultipleDevicesBody) (*CreateMultipleDevicesOutput, error) {
-	return s.CreateMultipleWithAsyncContext(context.Background(), body)
+// CreateMultipleWithAsyncWithResponse create multiple new devices with asynchronous job.
+func (s *DeviceService) CreateMultipleWithAsyncWithResponse(body *CreateMultipleDevicesBody) (*CreateMultipleDevicesOutput, *Response, error) {
+	return s.CreateMultipleWithAsyncWithResponseContext(context.Background(), body)
 }
 
-func (s *DeviceService) CreateMultipleWithAsyncContext(ctx context.Context, body *CreateMultipleDevicesBody) (*CreateMultipleDevicesOutput, error) {
-	req, err := s.client.newRequest(ctx, "POST", "/devices/bulk", body)
+// CreateMultipleWithAsyncWithResponseContext create multiple new devices with asynchronous job with context.
+func (s *DeviceService) CreateMultipleWithAsyncWithResponseContext(ctx context.Context, body *CreateMultipleDevicesBody) (*CreateMultipleDevicesOutput, *Response, error) {
+	var output CreateMultipleDevicesOutput
+	res, err := s.client.call(ctx, "POST", "/devices/bulk", body, &output)
 	if err != nil {
-		return nil, err
+		return nil, res, err
+	}
+
+	return &output, res, nil
+}
+
+// DeleteWithResponse delete a device.
+func (s *DeviceService) DeleteWithResponse(deviceID string) (*Response, error) {
+	return s.DeleteWithResponseContext(context.Background(), deviceID)
+}
+
+// DeleteWithResponseContext delete a device with context.
+func (s *DeviceService) DeleteWithResponseContext(ctx context.Context, deviceID string) (*Response, error) {
+	spath := fmt.Sprintf("/devices/%s", deviceID)
+	return s.client.call(ctx, "DELETE", spath, nil, nil)
+}
+
+type BulkJobOutput struct {
+	Total int32  `json:"total,omitempty"`
+	JobID string `json:"jobId,omitempty"`
+}
+
+type TransferDeviceBody struct {
+	DeviceTypeID string `json:"deviceTypeId"`
+	KeepHistory  bool   `json:"keepHistory,omitempty"`
+	Activable    bool   `json:"activable,omitempty"`
+}
+
+// TransferWithResponse transfer a device to another device type.
+// The API only exposes a bulk transfer, so a job holding this single device is started.
+func (s *DeviceService) TransferWithResponse(deviceID string, body *TransferDeviceBody) (*BulkJobOutput, *Response, error) {
+	return s.TransferWithResponseContext(context.Background(), deviceID, body)
+}
+
+// TransferWithResponseContext transfer a device to another device type with context.
+func (s *DeviceService) TransferWithResponseContext(ctx context.Context, deviceID string, body *TransferDeviceBody) (*BulkJobOutput, *Response, error) {
+	var errs fieldErrors
+	if body == nil {
+		errs.add("body", "transfer body is required")
+	} else if body.DeviceTypeID == "" {
+		errs.add("deviceTypeId", "device type ID is required")
+	}
+	if err := errs.err(); err != nil {
+		return nil, nil, err
 	}
 
-	res, err := s.client.Do(ctx, req)
+	bulk := &TransferMultipleDevicesBody{
+		DeviceTypeID: body.DeviceTypeID,
+		Data: []*DeviceTransferBulk{
+			{ID: deviceID, KeepHistory: body.KeepHistory, Activable: body.Activable},
+		},
+	}
+	return s.TransferMultipleWithAsyncWithResponseContext(ctx, bulk)
+}
+
+type TransferMultipleDevicesBody struct {
+	DeviceTypeID string                `json:"deviceTypeId"`
+	Data         []*DeviceTransferBulk `json:"data"`
+}
+
+type DeviceTransferBulk struct {
+	ID          string `json:"id"`
+	KeepHistory bool   `json:"keepHistory,omitempty"`
+	Activable   bool   `json:"activable,omitempty"`
+}
+
+// TransferMultipleWithAsyncWithResponse transfer multiple devices to another device type with asynchronous job.
+func (s *DeviceService) TransferMultipleWithAsyncWithResponse(body *TransferMultipleDevicesBody) (*BulkJobOutput, *Response, error) {
+	return s.TransferMultipleWithAsyncWithResponseContext(context.Background(), body)
+}
+
+// TransferMultipleWithAsyncWithResponseContext transfer multiple devices to another device type with asynchronous job with context.
+func (s *DeviceService) TransferMultipleWithAsyncWithResponseContext(ctx context.Context, body *TransferMultipleDevicesBody) (*BulkJobOutput, *Response, error) {
+	var output BulkJobOutput
+	res, err := s.client.call(ctx, "POST", "/devices/bulk/transfer", body, &output)
 	if err != nil {
-		return nil, err
+		return nil, res, err
 	}
 
-	var output CreateMultipleDevicesOutput
-	if err := decodeBody(res, &output); err != nil {
+	return &output, res, nil
+}
+
+type ReplaceMultipleDevicesBody struct {
+	Data []*DeviceReplaceBulk `json:"data"`
+}
+
+type DeviceReplaceBulk struct {
+	DeviceID       string `json:"deviceId"`
+	TargetDeviceID string `json:"targetDeviceId"`
+}
+
+// ReplaceWithResponse replace a failed device by a new one, the new device taking over the ID of the failed one.
+// The API only exposes a bulk replacement, so a job holding this single device is started.
+func (s *DeviceService) ReplaceWithResponse(deviceID, targetDeviceID string) (*BulkJobOutput, *Response, error) {
+	return s.ReplaceWithResponseContext(context.Background(), deviceID, targetDeviceID)
+}
+
+// ReplaceWithResponseContext replace a failed device by a new one with context.
+func (s *DeviceService) ReplaceWithResponseContext(ctx context.Context, deviceID, targetDeviceID string) (*BulkJobOutput, *Response, error) {
+	var errs fieldErrors
+	if deviceID == "" {
+		errs.add("deviceId", "device ID is required")
+	}
+	if targetDeviceID == "" {
+		errs.add("targetDeviceId", "target device ID is required")
+	}
+	if err := errs.err(); err != nil {
+		return nil, nil, err
+	}
+
+	bulk := &ReplaceMultipleDevicesBody{
+		Data: []*DeviceReplaceBulk{
+			{DeviceID: deviceID, TargetDeviceID: targetDeviceID},
+		},
+	}
+	return s.ReplaceMultipleWithAsyncWithResponseContext(ctx, bulk)
+}
+
+// ReplaceMultipleWithAsyncWithResponse replace multiple devices with asynchronous job.
+func (s *DeviceService) ReplaceMultipleWithAsyncWithResponse(body *ReplaceMultipleDevicesBody) (*BulkJobOutput, *Response, error) {
+	return s.ReplaceMultipleWithAsyncWithResponseContext(context.Background(), body)
+}
+
+// ReplaceMultipleWithAsyncWithResponseContext replace multiple devices with asynchronous job with context.
+func (s *DeviceService) ReplaceMultipleWithAsyncWithResponseContext(ctx context.Context, body *ReplaceMultipleDevicesBody) (*BulkJobOutput, *Response, error) {
+	var output BulkJobOutput
+	res, err := s.client.call(ctx, "POST", "/devices/bulk/replace", body, &output)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return &output, res, nil
+}
+
+type UnsubscribeDeviceBody struct {
+	UnsubscriptionTime Timestamp `json:"unsubscriptionTime"`
+}
+
+// UnsubscribeWithResponse unsubscribe a device at the given end date, in milliseconds since the Unix Epoch.
+func (s *DeviceService) UnsubscribeWithResponse(deviceID string, body *UnsubscribeDeviceBody) (*Response, error) {
+	return s.UnsubscribeWithResponseContext(context.Background(), deviceID, body)
+}
+
+// UnsubscribeWithResponseContext unsubscribe a device at the given end date with context.
+func (s *DeviceService) UnsubscribeWithResponseContext(ctx context.Context, deviceID string, body *UnsubscribeDeviceBody) (*Response, error) {
+	var errs fieldErrors
+	if body == nil {
+		errs.add("body", "unsubscribe body is required")
+	} else if body.UnsubscriptionTime == 0 {
+		errs.add("unsubscriptionTime", "unsubscription time is required")
+	}
+	if err := errs.err(); err != nil {
 		return nil, err
 	}
-	return &output, nil
+
+	spath := fmt.Sprintf("/devices/%s/unsubscribe", deviceID)
+	return s.client.call(ctx, "PUT", spath, body, nil)
+}
+
+type UnsubscribeMultipleDevicesBody struct {
+	Data []*DeviceUnsubscribeBulk `json:"data"`
+}
+
+type DeviceUnsubscribeBulk struct {
+	ID                 string    `json:"id"`
+	UnsubscriptionTime Timestamp `json:"unsubscriptionTime"`
+}
+
+// UnsubscribeMultipleWithAsyncWithResponse unsubscribe multiple devices with asynchronous job.
+func (s *DeviceService) UnsubscribeMultipleWithAsyncWithResponse(body *UnsubscribeMultipleDevicesBody) (*BulkJobOutput, *Response, error) {
+	return s.UnsubscribeMultipleWithAsyncWithResponseContext(context.Background(), body)
+}
+
+// UnsubscribeMultipleWithAsyncWithResponseContext unsubscribe multiple devices with asynchronous job with context.
+func (s *DeviceService) UnsubscribeMultipleWithAsyncWithResponseContext(ctx context.Context, body *UnsubscribeMultipleDevicesBody) (*BulkJobOutput, *Response, error) {
+	var output BulkJobOutput
+	res, err := s.client.call(ctx, "POST", "/devices/bulk/unsubscribe", body, &output)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return &output, res, nil
+}
+
+type RestartMultipleDevicesBody struct {
+	Data []*DeviceRestartBulk `json:"data"`
+}
+
+type DeviceRestartBulk struct {
+	ID string `json:"id"`
+}
+
+// RestartMultipleWithAsyncWithResponse restart multiple devices with asynchronous job.
+func (s *DeviceService) RestartMultipleWithAsyncWithResponse(body *RestartMultipleDevicesBody) (*BulkJobOutput, *Response, error) {
+	return s.RestartMultipleWithAsyncWithResponseContext(context.Background(), body)
+}
+
+// RestartMultipleWithAsyncWithResponseContext restart multiple devices with asynchronous job with context.
+func (s *DeviceService) RestartMultipleWithAsyncWithResponseContext(ctx context.Context, body *RestartMultipleDevicesBody) (*BulkJobOutput, *Response, error) {
+	var output BulkJobOutput
+	res, err := s.client.call(ctx, "POST", "/devices/bulk/restart", body, &output)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return &output, res, nil
 }
@@ -0,0 +1,35 @@
+package sigfox
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTransferReplaceUnsubscribe_validation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	}))
+	defer server.Close()
+
+	c, _ := NewClient("LOGIN_ID", "PASSWORD", WithBaseURL(server.URL))
+	ctx := context.Background()
+
+	if _, _, err := c.Device.TransferWithResponseContext(ctx, "d1", nil); !errors.Is(err, ErrValidation) {
+		t.Errorf("TransferWithResponseContext with a nil body returned %v, want a validation error", err)
+	}
+	if _, _, err := c.Device.TransferWithResponseContext(ctx, "d1", &TransferDeviceBody{}); !errors.Is(err, ErrValidation) {
+		t.Errorf("TransferWithResponseContext without a device type returned %v, want a validation error", err)
+	}
+	if _, _, err := c.Device.ReplaceWithResponseContext(ctx, "d1", ""); !errors.Is(err, ErrValidation) {
+		t.Errorf("ReplaceWithResponseContext without a target returned %v, want a validation error", err)
+	}
+	if _, err := c.Device.UnsubscribeWithResponseContext(ctx, "d1", nil); !errors.Is(err, ErrValidation) {
+		t.Errorf("UnsubscribeWithResponseContext with a nil body returned %v, want a validation error", err)
+	}
+	if _, err := c.Device.UnsubscribeWithResponseContext(ctx, "d1", &UnsubscribeDeviceBody{}); !errors.Is(err, ErrValidation) {
+		t.Errorf("UnsubscribeWithResponseContext without a time returned %v, want a validation error", err)
+	}
+}
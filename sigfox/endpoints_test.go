@@ -0,0 +1,323 @@
+package sigfox
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type endpointTest struct {
+	name   string
+	method string
+	path   string
+	call   func(ctx context.Context, c *Client) error
+}
+
+var endpointTests = []endpointTest{
+	{"ApiUser.List", "GET", "/v2/api-users", func(ctx context.Context, c *Client) error {
+		_, _, err := c.ApiUser.ListWithResponseContext(ctx)
+		return err
+	}},
+	{"ApiUser.Info", "GET", "/v2/api-users/u1", func(ctx context.Context, c *Client) error {
+		_, _, err := c.ApiUser.InfoWithResponseContext(ctx, "u1")
+		return err
+	}},
+	{"Contract.List", "GET", "/v2/contract-infos", func(ctx context.Context, c *Client) error {
+		_, _, err := c.Contract.ListWithResponseContext(ctx, nil)
+		return err
+	}},
+	{"Contract.Info", "GET", "/v2/contract-infos/c1", func(ctx context.Context, c *Client) error {
+		_, _, err := c.Contract.InfoWithResponseContext(ctx, "c1")
+		return err
+	}},
+	{"Contract.ListDevices", "GET", "/v2/contract-infos/c1/devices", func(ctx context.Context, c *Client) error {
+		_, _, err := c.Contract.ListDevicesWithResponseContext(ctx, "c1", nil)
+		return err
+	}},
+	{"Coverage.Predictions", "GET", "/v2/coverages/global/predictions", func(ctx context.Context, c *Client) error {
+		_, _, err := c.Coverage.PredictionsWithResponseContext(ctx, &CoveragePredictionInput{Lat: 1, Lng: 2})
+		return err
+	}},
+	{"Coverage.BatchPredictions", "POST", "/v2/coverages/global/predictions", func(ctx context.Context, c *Client) error {
+		_, _, err := c.Coverage.BatchPredictionsWithResponseContext(ctx, &CoverageBatchPredictionInput{})
+		return err
+	}},
+	{"Coverage.Redundancy", "GET", "/v2/coverages/operators/redundancy", func(ctx context.Context, c *Client) error {
+		_, _, err := c.Coverage.RedundancyWithResponseContext(ctx, &CoverageRedundancyInput{Lat: 1, Lng: 2})
+		return err
+	}},
+	{"Device.List", "GET", "/v2/devices", func(ctx context.Context, c *Client) error {
+		_, _, err := c.Device.ListWithResponseContext(ctx, nil)
+		return err
+	}},
+	{"Device.Create", "POST", "/v2/devices", func(ctx context.Context, c *Client) error {
+		_, _, err := c.Device.CreateWithResponseContext(ctx, &CreateDeviceBody{})
+		return err
+	}},
+	{"Device.Info", "GET", "/v2/devices/d1", func(ctx context.Context, c *Client) error {
+		_, _, err := c.Device.InfoWithResponseContext(ctx, "d1")
+		return err
+	}},
+	{"Device.Update", "PUT", "/v2/devices/d1", func(ctx context.Context, c *Client) error {
+		_, err := c.Device.UpdateWithResponseContext(ctx, "d1", &UpdateDeviceBody{})
+		return err
+	}},
+	{"Device.ListUndeliveredCallbacks", "GET", "/v2/devices/d1/callbacks-not-delivered", func(ctx context.Context, c *Client) error {
+		_, _, err := c.Device.ListUndeliveredCallbacksWithResponseContext(ctx, "d1", nil)
+		return err
+	}},
+	{"Device.DisengageSequenceNumber", "POST", "/v2/devices/d1/disengage", func(ctx context.Context, c *Client) error {
+		_, err := c.Device.DisengageSequenceNumberWithResponseContext(ctx, "d1")
+		return err
+	}},
+	{"Device.Messages", "GET", "/v2/devices/d1/messages", func(ctx context.Context, c *Client) error {
+		_, _, err := c.Device.MessagesWithResponseContext(ctx, "d1")
+		return err
+	}},
+	{"Device.Locations", "GET", "/v2/devices/d1/locations", func(ctx context.Context, c *Client) error {
+		_, _, err := c.Device.LocationsWithResponseContext(ctx, "d1")
+		return err
+	}},
+	{"Device.Metric", "GET", "/v2/devices/d1/messages/metric", func(ctx context.Context, c *Client) error {
+		_, _, err := c.Device.MetricWithResponseContext(ctx, "d1")
+		return err
+	}},
+	{"Device.Consumption", "GET", "/v2/devices/d1/consumption", func(ctx context.Context, c *Client) error {
+		_, _, err := c.Device.ConsumptionWithResponseContext(ctx, "d1")
+		return err
+	}},
+	{"Device.YearConsumption", "GET", "/v2/devices/d1/consumptions/2020", func(ctx context.Context, c *Client) error {
+		_, _, err := c.Device.YearConsumptionWithResponseContext(ctx, "d1", 2020)
+		return err
+	}},
+	{"Device.MonthConsumption", "GET", "/v2/devices/d1/consumptions/2020/9", func(ctx context.Context, c *Client) error {
+		_, _, err := c.Device.MonthConsumptionWithResponseContext(ctx, "d1", 2020, time.September)
+		return err
+	}},
+	{"Device.CreateMultipleWithAsync", "POST", "/v2/devices/bulk", func(ctx context.Context, c *Client) error {
+		_, _, err := c.Device.CreateMultipleWithAsyncWithResponseContext(ctx, &CreateMultipleDevicesBody{})
+		return err
+	}},
+	{"Device.Delete", "DELETE", "/v2/devices/d1", func(ctx context.Context, c *Client) error {
+		_, err := c.Device.DeleteWithResponseContext(ctx, "d1")
+		return err
+	}},
+	{"Device.Transfer", "POST", "/v2/devices/bulk/transfer", func(ctx context.Context, c *Client) error {
+		_, _, err := c.Device.TransferWithResponseContext(ctx, "d1", &TransferDeviceBody{DeviceTypeID: "t1"})
+		return err
+	}},
+	{"Device.Replace", "POST", "/v2/devices/bulk/replace", func(ctx context.Context, c *Client) error {
+		_, _, err := c.Device.ReplaceWithResponseContext(ctx, "d1", "d2")
+		return err
+	}},
+	{"Device.Unsubscribe", "PUT", "/v2/devices/d1/unsubscribe", func(ctx context.Context, c *Client) error {
+		_, err := c.Device.UnsubscribeWithResponseContext(ctx, "d1", &UnsubscribeDeviceBody{UnsubscriptionTime: 1})
+		return err
+	}},
+	{"Device.UnsubscribeMultipleWithAsync", "POST", "/v2/devices/bulk/unsubscribe", func(ctx context.Context, c *Client) error {
+		_, _, err := c.Device.UnsubscribeMultipleWithAsyncWithResponseContext(ctx, &UnsubscribeMultipleDevicesBody{})
+		return err
+	}},
+	{"Device.RestartMultipleWithAsync", "POST", "/v2/devices/bulk/restart", func(ctx context.Context, c *Client) error {
+		_, _, err := c.Device.RestartMultipleWithAsyncWithResponseContext(ctx, &RestartMultipleDevicesBody{})
+		return err
+	}},
+	{"Device.Iterate", "GET", "/v2/devices", func(ctx context.Context, c *Client) error {
+		it := c.Device.Iterate(ctx, nil, nil)
+		for it.Next() {
+		}
+		return it.Err()
+	}},
+	{"DeviceType.List", "GET", "/v2/device-types", func(ctx context.Context, c *Client) error {
+		_, _, err := c.DeviceType.ListWithResponseContext(ctx, nil)
+		return err
+	}},
+	{"DeviceType.Create", "POST", "/v2/device-types", func(ctx context.Context, c *Client) error {
+		_, _, err := c.DeviceType.CreateWithResponseContext(ctx, &CreateDeviceTypeInput{})
+		return err
+	}},
+	{"DeviceType.Info", "GET", "/v2/device-types/t1", func(ctx context.Context, c *Client) error {
+		_, _, err := c.DeviceType.InfoWithResponseContext(ctx, "t1")
+		return err
+	}},
+	{"DeviceType.Delete", "DELETE", "/v2/device-types/t1", func(ctx context.Context, c *Client) error {
+		_, err := c.DeviceType.DeleteWithResponseContext(ctx, "t1")
+		return err
+	}},
+	{"DeviceType.ListMessages", "GET", "/v2/device-types/t1/messages", func(ctx context.Context, c *Client) error {
+		_, _, err := c.DeviceType.ListMessagesWithResponseContext(ctx, "t1")
+		return err
+	}},
+	{"DeviceType.ListCallbackErrors", "GET", "/v2/device-types/t1/callbacks-not-delivered", func(ctx context.Context, c *Client) error {
+		_, _, err := c.DeviceType.ListCallbackErrorsWithResponseContext(ctx, "t1", nil)
+		return err
+	}},
+	{"DeviceType.ListCallbacks", "GET", "/v2/device-types/t1/callbacks", func(ctx context.Context, c *Client) error {
+		_, _, err := c.DeviceType.ListCallbacksWithResponseContext(ctx, "t1")
+		return err
+	}},
+	{"DeviceType.CreateCallback", "POST", "/v2/device-types/t1/callbacks", func(ctx context.Context, c *Client) error {
+		_, _, err := c.DeviceType.CreateCallbackWithResponseContext(ctx, "t1", &CreateCallbackInput{Callbacks: Callbacks{CallbackSubtype: CallbackSubtypeUplink}})
+		return err
+	}},
+	{"DeviceType.UpdateCallback", "PUT", "/v2/device-types/t1/callbacks/c1", func(ctx context.Context, c *Client) error {
+		_, err := c.DeviceType.UpdateCallbackWithResponseContext(ctx, "t1", "c1", &UpdateCallbackInput{Callbacks: Callbacks{URL: "https://example.com"}})
+		return err
+	}},
+	{"DeviceType.Update", "PUT", "/v2/device-types/t1", func(ctx context.Context, c *Client) error {
+		_, err := c.DeviceType.UpdateWithResponseContext(ctx, "t1", &UpdateDeviceTypeInput{})
+		return err
+	}},
+	{"DeviceType.DeleteCallback", "DELETE", "/v2/device-types/t1/callbacks/c1", func(ctx context.Context, c *Client) error {
+		_, err := c.DeviceType.DeleteCallbackWithResponseContext(ctx, "t1", "c1")
+		return err
+	}},
+	{"DeviceType.EnableCallback", "PUT", "/v2/device-types/t1/callbacks/c1/enable", func(ctx context.Context, c *Client) error {
+		_, err := c.DeviceType.EnableCallbackWithResponseContext(ctx, "t1", "c1", false)
+		return err
+	}},
+	{"DeviceType.AcknowledgeCallbackErrors", "PUT", "/v2/device-types/t1/callbacks/c1/callbacks-not-delivered", func(ctx context.Context, c *Client) error {
+		_, err := c.DeviceType.AcknowledgeCallbackErrorsWithResponseContext(ctx, "t1", "c1")
+		return err
+	}},
+	{"DeviceType.SelectDownlinkCallback", "PUT", "/v2/device-types/t1/callbacks/c1/downlink", func(ctx context.Context, c *Client) error {
+		_, err := c.DeviceType.SelectDownlinkCallbackWithResponseContext(ctx, "t1", "c1")
+		return err
+	}},
+	{"Group.List", "GET", "/v2/groups", func(ctx context.Context, c *Client) error {
+		_, _, err := c.Group.ListWithResponseContext(ctx, nil)
+		return err
+	}},
+	{"Group.Info", "GET", "/v2/groups/g1", func(ctx context.Context, c *Client) error {
+		_, _, err := c.Group.InfoWithResponseContext(ctx, "g1")
+		return err
+	}},
+	{"Group.Create", "POST", "/v2/groups", func(ctx context.Context, c *Client) error {
+		_, _, err := c.Group.CreateWithResponseContext(ctx, &CreateGroupInput{})
+		return err
+	}},
+	{"Group.Update", "PUT", "/v2/groups/g1", func(ctx context.Context, c *Client) error {
+		_, err := c.Group.UpdateWithResponseContext(ctx, "g1", &UpdateGroupInput{})
+		return err
+	}},
+	{"Group.Delete", "DELETE", "/v2/groups/g1", func(ctx context.Context, c *Client) error {
+		_, err := c.Group.DeleteWithResponseContext(ctx, "g1")
+		return err
+	}},
+	{"Group.ListUndeliveredCallbacks", "GET", "/v2/groups/g1/callbacks-not-delivered", func(ctx context.Context, c *Client) error {
+		_, _, err := c.Group.ListUndeliveredCallbacksWithResponseContext(ctx, "g1", nil)
+		return err
+	}},
+	{"Group.ListGeolocPayloads", "GET", "/v2/groups/g1/geoloc-payloads", func(ctx context.Context, c *Client) error {
+		_, _, err := c.Group.ListGeolocPayloadsWithResponseContext(ctx, "g1", nil)
+		return err
+	}},
+	{"Profile.List", "GET", "/v2/profiles", func(ctx context.Context, c *Client) error {
+		_, _, err := c.Profile.ListWithResponseContext(ctx, nil)
+		return err
+	}},
+	{"Tile.Monarch", "GET", "/v2/tiles/monarch", func(ctx context.Context, c *Client) error {
+		_, _, err := c.Tile.MonarchWithResponseContext(ctx)
+		return err
+	}},
+	{"User.List", "GET", "/v2/users", func(ctx context.Context, c *Client) error {
+		_, _, err := c.User.ListWithResponseContext(ctx)
+		return err
+	}},
+	{"User.Info", "GET", "/v2/users/u1", func(ctx context.Context, c *Client) error {
+		_, _, err := c.User.InfoWithResponseContext(ctx, "u1")
+		return err
+	}},
+	{"User.Create", "POST", "/v2/users", func(ctx context.Context, c *Client) error {
+		_, _, err := c.User.CreateWithResponseContext(ctx, &CreateUserInput{
+			FirstName: "Jane", LastName: "Doe", Email: "jane@example.com", Timezone: "Europe/Paris",
+			UserRoles: []UserRoleInput{{GroupID: "g1", ProfileID: "p1"}},
+		})
+		return err
+	}},
+	{"User.Update", "PUT", "/v2/users/u1", func(ctx context.Context, c *Client) error {
+		_, err := c.User.UpdateWithResponseContext(ctx, "u1", &UpdateUserInput{})
+		return err
+	}},
+	{"User.Delete", "DELETE", "/v2/users/u1", func(ctx context.Context, c *Client) error {
+		_, err := c.User.DeleteWithResponseContext(ctx, "u1")
+		return err
+	}},
+	{"User.AddProfiles", "PUT", "/v2/users/u1/profiles", func(ctx context.Context, c *Client) error {
+		_, err := c.User.AddProfilesWithResponseContext(ctx, "u1", &AddUserProfilesInput{UserRoles: []UserRoleInput{{GroupID: "g1", ProfileID: "p1"}}})
+		return err
+	}},
+	{"User.RemoveProfile", "DELETE", "/v2/users/u1/profiles/g1", func(ctx context.Context, c *Client) error {
+		_, err := c.User.RemoveProfileWithResponseContext(ctx, "u1", "g1", "p1")
+		return err
+	}},
+}
+
+func TestEndpoints_errors(t *testing.T) {
+	statuses := []struct {
+		code   int
+		target error
+	}{
+		{http.StatusBadRequest, ErrValidation},
+		{http.StatusUnauthorized, ErrUnauthorized},
+		{http.StatusNotFound, ErrNotFound},
+	}
+
+	for _, st := range statuses {
+		var got string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if _, _, ok := r.BasicAuth(); !ok {
+				t.Errorf("%s %s sent without credentials", r.Method, r.URL.Path)
+			}
+			got = r.Method + " " + r.URL.Path
+			w.WriteHeader(st.code)
+			w.Write([]byte(`{"message":"failure"}`))
+		}))
+
+		c, _ := NewClient("LOGIN_ID", "PASSWORD", WithBaseURL(server.URL+"/v2"))
+		c.RetryPolicy = nil
+
+		for _, tt := range endpointTests {
+			got = ""
+			err := tt.call(context.Background(), c)
+
+			if want := tt.method + " " + tt.path; got != want {
+				t.Errorf("%s sent %q, want %q", tt.name, got, want)
+			}
+			if !errors.Is(err, st.target) {
+				t.Errorf("%s with status %d returned %v, want %v", tt.name, st.code, err, st.target)
+			}
+		}
+
+		server.Close()
+	}
+}
+
+func TestEndpoints_hooks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	var requests, failures int
+	c, _ := NewClient("LOGIN_ID", "PASSWORD",
+		WithBaseURL(server.URL),
+		WithRequestHook(func(req *http.Request) { requests++ }),
+		WithResponseHook(func(req *http.Request, resp *http.Response, err error) {
+			if errors.Is(err, ErrForbidden) {
+				failures++
+			}
+		}),
+	)
+
+	for _, tt := range endpointTests {
+		tt.call(context.Background(), c)
+	}
+
+	if requests != len(endpointTests) || failures != len(endpointTests) {
+		t.Errorf("hooks saw %d requests and %d failures, want %d", requests, failures, len(endpointTests))
+	}
+}
@@ -0,0 +1,297 @@
+package sigfox
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// The enumerations of the API are integers. Their types print their names,
+// and decode from either the integer or the name, case insensitively.
+// They are encoded as integers, as expected by the API.
+
+// enumNames maps the values of an enumeration to their names.
+type enumNames map[int32]string
+
+func (names enumNames) format(v int32) string {
+	if name, ok := names[v]; ok {
+		return name
+	}
+	return strconv.Itoa(int(v))
+}
+
+func (names enumNames) valid(v int32) bool {
+	_, ok := names[v]
+	return ok
+}
+
+// parse decodes a JSON integer, or a JSON string holding a name or an integer.
+// Unknown integers are kept, so that values added to the API are not rejected.
+func (names enumNames) parse(data []byte, kind string) (int32, error) {
+	var n int32
+	if err := json.Unmarshal(data, &n); err == nil {
+		return n, nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return 0, fmt.Errorf("invalid %s %s", kind, data)
+	}
+	for v, name := range names {
+		if strings.EqualFold(name, s) {
+			return v, nil
+		}
+	}
+	if i, err := strconv.ParseInt(s, 10, 32); err == nil {
+		return int32(i), nil
+	}
+	return 0, fmt.Errorf("unknown %s %q", kind, s)
+}
+
+// DeviceState is the state of a device.
+type DeviceState int32
+
+const (
+	DeviceStateOK           DeviceState = 0
+	DeviceStateDead         DeviceState = 1
+	DeviceStateOffContract  DeviceState = 2
+	DeviceStateDisabled     DeviceState = 3
+	DeviceStateWarn         DeviceState = 4
+	DeviceStateDeleted      DeviceState = 5
+	DeviceStateSuspended    DeviceState = 6
+	DeviceStateNotActivable DeviceState = 7
+)
+
+var deviceStateNames = enumNames{
+	0: "OK", 1: "DEAD", 2: "OFF_CONTRACT", 3: "DISABLED",
+	4: "WARN", 5: "DELETED", 6: "SUSPENDED", 7: "NOT_ACTIVABLE",
+}
+
+func (v DeviceState) String() string { return deviceStateNames.format(int32(v)) }
+
+// Valid reports whether v is a known device state.
+func (v DeviceState) Valid() bool { return deviceStateNames.valid(int32(v)) }
+
+func (v *DeviceState) UnmarshalJSON(data []byte) error {
+	n, err := deviceStateNames.parse(data, "device state")
+	*v = DeviceState(n)
+	return err
+}
+
+// ComState is the communication state of a device.
+type ComState int32
+
+const (
+	ComStateOK        ComState = 0
+	ComStateDead      ComState = 1
+	ComStateNA        ComState = 2
+	ComStateNotSeen   ComState = 3
+	ComStateNeverSeen ComState = 4
+)
+
+var comStateNames = enumNames{
+	0: "OK", 1: "DEAD", 2: "NA", 3: "NOT_SEEN", 4: "NEVER_SEEN",
+}
+
+func (v ComState) String() string { return comStateNames.format(int32(v)) }
+
+// Valid reports whether v is a known communication state.
+func (v ComState) Valid() bool { return comStateNames.valid(int32(v)) }
+
+func (v *ComState) UnmarshalJSON(data []byte) error {
+	n, err := comStateNames.parse(data, "communication state")
+	*v = ComState(n)
+	return err
+}
+
+// AutomaticRenewalStatus is the status of the automatic renewal of the token of a device.
+type AutomaticRenewalStatus int32
+
+const (
+	AutomaticRenewalAllowed    AutomaticRenewalStatus = 0
+	AutomaticRenewalNotAllowed AutomaticRenewalStatus = 1
+	AutomaticRenewalRenewed    AutomaticRenewalStatus = 2
+	AutomaticRenewalEnded      AutomaticRenewalStatus = 3
+)
+
+var automaticRenewalStatusNames = enumNames{
+	0: "ALLOWED", 1: "NOT_ALLOWED", 2: "RENEWED", 3: "ENDED",
+}
+
+func (v AutomaticRenewalStatus) String() string {
+	return automaticRenewalStatusNames.format(int32(v))
+}
+
+// Valid reports whether v is a known automatic renewal status.
+func (v AutomaticRenewalStatus) Valid() bool { return automaticRenewalStatusNames.valid(int32(v)) }
+
+func (v *AutomaticRenewalStatus) UnmarshalJSON(data []byte) error {
+	n, err := automaticRenewalStatusNames.parse(data, "automatic renewal status")
+	*v = AutomaticRenewalStatus(n)
+	return err
+}
+
+// PayloadType is how the payloads of the messages of a device type are displayed.
+type PayloadType int32
+
+const (
+	PayloadTypeRegular       PayloadType = 2
+	PayloadTypeCustomGrammar PayloadType = 3
+	PayloadTypeGeolocation   PayloadType = 4
+	PayloadTypeDisplayASCII  PayloadType = 5
+	PayloadTypeRadioPlanning PayloadType = 6
+	PayloadTypeSensitV2      PayloadType = 9
+)
+
+var payloadTypeNames = enumNames{
+	2: "REGULAR", 3: "CUSTOM_GRAMMAR", 4: "GEOLOCATION",
+	5: "DISPLAY_ASCII", 6: "RADIO_PLANNING", 9: "SENSITV2",
+}
+
+func (v PayloadType) String() string { return payloadTypeNames.format(int32(v)) }
+
+// Valid reports whether v is a known payload type.
+func (v PayloadType) Valid() bool { return payloadTypeNames.valid(int32(v)) }
+
+func (v *PayloadType) UnmarshalJSON(data []byte) error {
+	n, err := payloadTypeNames.parse(data, "payload type")
+	*v = PayloadType(n)
+	return err
+}
+
+// DownlinkMode is how the downlink data of a device type is provided.
+type DownlinkMode int32
+
+const (
+	DownlinkModeDirect   DownlinkMode = 0
+	DownlinkModeCallback DownlinkMode = 1
+	DownlinkModeNone     DownlinkMode = 2
+	DownlinkModeManaged  DownlinkMode = 3
+)
+
+var downlinkModeNames = enumNames{
+	0: "DIRECT", 1: "CALLBACK", 2: "NONE", 3: "MANAGED",
+}
+
+func (v DownlinkMode) String() string { return downlinkModeNames.format(int32(v)) }
+
+// Valid reports whether v is a known downlink mode.
+func (v DownlinkMode) Valid() bool { return downlinkModeNames.valid(int32(v)) }
+
+func (v *DownlinkMode) UnmarshalJSON(data []byte) error {
+	n, err := downlinkModeNames.parse(data, "downlink mode")
+	*v = DownlinkMode(n)
+	return err
+}
+
+// GroupType is the type of a group.
+type GroupType int32
+
+const (
+	GroupTypeSO       GroupType = 0
+	GroupTypeOther    GroupType = 2
+	GroupTypeSVNO     GroupType = 5
+	GroupTypePartners GroupType = 6
+	GroupTypeNIP      GroupType = 7
+	GroupTypeDist     GroupType = 8
+	GroupTypeChannel  GroupType = 9
+	GroupTypeStarter  GroupType = 10
+	GroupTypePartner  GroupType = 11
+)
+
+var groupTypeNames = enumNames{
+	0: "SO", 2: "OTHER", 5: "SVNO", 6: "PARTNERS", 7: "NIP",
+	8: "DIST", 9: "CHANNEL", 10: "STARTER", 11: "PARTNER",
+}
+
+func (v GroupType) String() string { return groupTypeNames.format(int32(v)) }
+
+// Valid reports whether v is a known group type.
+func (v GroupType) Valid() bool { return groupTypeNames.valid(int32(v)) }
+
+func (v *GroupType) UnmarshalJSON(data []byte) error {
+	n, err := groupTypeNames.parse(data, "group type")
+	*v = GroupType(n)
+	return err
+}
+
+// GroupTypes is a list of group types, encoded as integers in query strings.
+type GroupTypes []GroupType
+
+// EncodeValues adds the group types to the query string under key.
+func (types GroupTypes) EncodeValues(key string, v *url.Values) error {
+	for _, t := range types {
+		v.Add(key, strconv.Itoa(int(t)))
+	}
+	return nil
+}
+
+// CallbackType is the type of a callback.
+type CallbackType int32
+
+const (
+	CallbackTypeData    CallbackType = 0
+	CallbackTypeService CallbackType = 1
+	CallbackTypeError   CallbackType = 2
+)
+
+var callbackTypeNames = enumNames{
+	0: "DATA", 1: "SERVICE", 2: "ERROR",
+}
+
+func (v CallbackType) String() string { return callbackTypeNames.format(int32(v)) }
+
+// Valid reports whether v is a known callback type.
+func (v CallbackType) Valid() bool { return callbackTypeNames.valid(int32(v)) }
+
+func (v *CallbackType) UnmarshalJSON(data []byte) error {
+	n, err := callbackTypeNames.parse(data, "callback type")
+	*v = CallbackType(n)
+	return err
+}
+
+// CallbackSubtype is the subtype of a data or service callback.
+type CallbackSubtype int32
+
+const (
+	CallbackSubtypeStatus       CallbackSubtype = 0
+	CallbackSubtypeGeoloc       CallbackSubtype = 1
+	CallbackSubtypeUplink       CallbackSubtype = 2
+	CallbackSubtypeBidir        CallbackSubtype = 3
+	CallbackSubtypeAcknowledge  CallbackSubtype = 4
+	CallbackSubtypeRepeater     CallbackSubtype = 5
+	CallbackSubtypeDataAdvanced CallbackSubtype = 6
+)
+
+var callbackSubtypeNames = enumNames{
+	0: "STATUS", 1: "GEOLOC", 2: "UPLINK", 3: "BIDIR",
+	4: "ACKNOWLEDGE", 5: "REPEATER", 6: "DATA_ADVANCED",
+}
+
+func (v CallbackSubtype) String() string { return callbackSubtypeNames.format(int32(v)) }
+
+// Valid reports whether v is a known callback subtype.
+func (v CallbackSubtype) Valid() bool { return callbackSubtypeNames.valid(int32(v)) }
+
+func (v *CallbackSubtype) UnmarshalJSON(data []byte) error {
+	n, err := callbackSubtypeNames.parse(data, "callback subtype")
+	*v = CallbackSubtype(n)
+	return err
+}
+
+// ValidFor reports whether the subtype may be used with a callback type.
+// ERROR callbacks have no subtype, so any subtype is accepted for them.
+func (v CallbackSubtype) ValidFor(t CallbackType) bool {
+	switch t {
+	case CallbackTypeData:
+		return v == CallbackSubtypeUplink || v == CallbackSubtypeBidir || v == CallbackSubtypeDataAdvanced
+	case CallbackTypeService:
+		return v == CallbackSubtypeStatus || v == CallbackSubtypeGeoloc ||
+			v == CallbackSubtypeAcknowledge || v == CallbackSubtypeRepeater
+	case CallbackTypeError:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,98 @@
+package sigfox
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestEnums_JSON(t *testing.T) {
+	var d Device
+	if err := json.Unmarshal([]byte(`{"state":"off_contract","comState":1,"automaticRenewalStatus":"2"}`), &d); err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+	if d.State != DeviceStateOffContract || d.ComState != ComStateDead || d.AutomaticRenewalStatus != AutomaticRenewalRenewed {
+		t.Errorf("Unmarshal decoded %v, %v, %v", d.State, d.ComState, d.AutomaticRenewalStatus)
+	}
+
+	b, err := json.Marshal(Callbacks{CallbackType: CallbackTypeService, CallbackSubtype: CallbackSubtypeGeoloc})
+	if err != nil {
+		t.Fatalf("Marshal returned error: %v", err)
+	}
+	if want := `{"id":"","channel":"","callbackType":1,"callbackSubtype":1,"enabled":false,"sendDuplicate":false}`; string(b) != want {
+		t.Errorf("Marshal returned %s, want %s", b, want)
+	}
+
+	// Unknown values are kept so that new values of the API can be decoded.
+	var g Group
+	if err := json.Unmarshal([]byte(`{"type":42}`), &g); err != nil || g.Type != 42 || g.Type.Valid() {
+		t.Errorf("Unmarshal decoded %v, %v", g.Type, err)
+	}
+	if got := g.Type.String(); got != "42" {
+		t.Errorf("String() is %q, want %q", got, "42")
+	}
+
+	if err := json.Unmarshal([]byte(`{"payloadType":"unknown"}`), &DeviceType{}); err == nil {
+		t.Error("Unmarshal accepted an unknown payload type name")
+	}
+}
+
+func TestEnums_String(t *testing.T) {
+	tests := []struct {
+		v    interface{ String() string }
+		want string
+	}{
+		{DeviceStateNotActivable, "NOT_ACTIVABLE"},
+		{ComStateOK, "OK"},
+		{AutomaticRenewalNotAllowed, "NOT_ALLOWED"},
+		{PayloadTypeCustomGrammar, "CUSTOM_GRAMMAR"},
+		{DownlinkModeManaged, "MANAGED"},
+		{GroupTypeSVNO, "SVNO"},
+		{CallbackTypeError, "ERROR"},
+		{CallbackSubtypeDataAdvanced, "DATA_ADVANCED"},
+	}
+	for _, tt := range tests {
+		if got := tt.v.String(); got != tt.want {
+			t.Errorf("String() is %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestInputs_Validate(t *testing.T) {
+	tests := []struct {
+		name  string
+		input interface{ Validate() error }
+		valid bool
+	}{
+		{"device type", &CreateDeviceTypeInput{PayloadType: PayloadTypeRegular, DownlinkMode: DownlinkModeCallback}, true},
+		{"payload type", &CreateDeviceTypeInput{PayloadType: 1}, false},
+		{"payload config", &UpdateDeviceTypeInput{PayloadType: PayloadTypeCustomGrammar, PayloadConfig: "temp::int"}, false},
+		{"downlink mode", &UpdateDeviceTypeInput{DownlinkMode: 7}, false},
+		{"group type", &CreateGroupInput{Type: 1}, false},
+		{"callback", &CreateCallbackInput{Callbacks: Callbacks{Channel: "URL", URL: "https://example.com", CallbackSubtype: CallbackSubtypeBidir}}, true},
+		{"callback subtype", &CreateCallbackInput{Callbacks: Callbacks{CallbackType: CallbackTypeService, CallbackSubtype: CallbackSubtypeUplink}}, false},
+		{"callback url", &UpdateCallbackInput{Callbacks: Callbacks{URL: "https://example.com"}}, true},
+		{"callback type", &UpdateCallbackInput{Callbacks: Callbacks{CallbackType: 5}}, false},
+		{"email recipient", &UpdateCallbackInput{Callbacks: Callbacks{Channel: "EMAIL", CallbackType: CallbackTypeError}}, false},
+	}
+
+	for _, tt := range tests {
+		err := tt.input.Validate()
+		if tt.valid && err != nil {
+			t.Errorf("%s: Validate returned error: %v", tt.name, err)
+		}
+		if !tt.valid && !errors.Is(err, ErrValidation) {
+			t.Errorf("%s: Validate returned %v, want a validation error", tt.name, err)
+		}
+	}
+}
+
+func TestGroupTypes_query(t *testing.T) {
+	spath, err := addOptions("/groups", &ListGroupsOptions{Types: []GroupType{GroupTypeSVNO, GroupTypeChannel}})
+	if err != nil {
+		t.Fatalf("addOptions returned error: %v", err)
+	}
+	if want := "/groups?types=5&types=9"; spath != want {
+		t.Errorf("addOptions returned %q, want %q", spath, want)
+	}
+}
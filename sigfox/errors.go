@@ -0,0 +1,217 @@
+package sigfox
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Sentinel errors matched with errors.Is against the errors returned by the client.
+var (
+	ErrUnauthorized = errors.New("sigfox: unauthorized")
+	ErrForbidden    = errors.New("sigfox: forbidden")
+	ErrNotFound     = errors.New("sigfox: not found")
+	ErrConflict     = errors.New("sigfox: conflict")
+	ErrRateLimited  = errors.New("sigfox: rate limited")
+	ErrValidation   = errors.New("sigfox: validation failed")
+)
+
+// requestIDHeaders lists the headers in which the backend may report the request ID.
+var requestIDHeaders = []string{"X-Request-Id", "X-Correlation-Id", "X-Amzn-Requestid"}
+
+// ErrorResponse reports an error returned by the Sigfox API.
+type ErrorResponse struct {
+	Response *http.Response
+	Message  string      `json:"message"`
+	Errors   FieldErrors `json:"errors,omitempty"`
+
+	// RequestID identifies the request on the backend side, when it is reported.
+	RequestID string `json:"-"`
+	// Body is the raw response body.
+	Body []byte `json:"-"`
+}
+
+func (r *ErrorResponse) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%v %v: %d %v",
+		r.Response.Request.Method, r.Response.Request.URL, r.Response.StatusCode, r.Message)
+	if len(r.Errors) > 0 {
+		fmt.Fprintf(&b, " (%v)", r.Errors)
+	}
+	if r.RequestID != "" {
+		fmt.Fprintf(&b, " [request id: %s]", r.RequestID)
+	}
+	return b.String()
+}
+
+// FieldError is an error about one field of the request.
+type FieldError struct {
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+func (e FieldError) String() string {
+	if e.Field == "" {
+		return e.Message
+	}
+	return e.Field + ": " + e.Message
+}
+
+// FieldErrors is the list of per-field errors of a rejected request.
+type FieldErrors []FieldError
+
+func (e FieldErrors) String() string {
+	s := make([]string, len(e))
+	for i, fe := range e {
+		s[i] = fe.String()
+	}
+	return strings.Join(s, ", ")
+}
+
+// UnmarshalJSON accepts the per-field errors given either as a list of objects
+// or as an object mapping each field to one or several messages.
+func (e *FieldErrors) UnmarshalJSON(data []byte) error {
+	var list []FieldError
+	if err := json.Unmarshal(data, &list); err == nil {
+		*e = list
+		return nil
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	*e = nil
+	for field, raw := range fields {
+		var msgs []string
+		if err := json.Unmarshal(raw, &msgs); err != nil {
+			var msg string
+			if err := json.Unmarshal(raw, &msg); err != nil {
+				msg = string(raw)
+			}
+			msgs = []string{msg}
+		}
+		for _, msg := range msgs {
+			*e = append(*e, FieldError{Field: field, Message: msg})
+		}
+	}
+	return nil
+}
+
+// InvalidInputError is returned without sending the request when an input holds invalid values.
+// It matches ErrValidation, like the validation errors returned by the API.
+type InvalidInputError struct {
+	Errors FieldErrors
+}
+
+func (e *InvalidInputError) Error() string {
+	return fmt.Sprintf("sigfox: invalid input (%v)", e.Errors)
+}
+
+func (e *InvalidInputError) Is(target error) bool { return target == ErrValidation }
+
+// fieldErrors collects the errors of an input.
+type fieldErrors FieldErrors
+
+func (e *fieldErrors) add(field, format string, args ...interface{}) {
+	*e = append(*e, FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+func (e fieldErrors) err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return &InvalidInputError{Errors: FieldErrors(e)}
+}
+
+// UnauthorizedError is returned on 401 Unauthorized responses.
+type UnauthorizedError struct{ *ErrorResponse }
+
+func (e *UnauthorizedError) Unwrap() error        { return e.ErrorResponse }
+func (e *UnauthorizedError) Is(target error) bool { return target == ErrUnauthorized }
+
+// ForbiddenError is returned on 403 Forbidden responses.
+type ForbiddenError struct{ *ErrorResponse }
+
+func (e *ForbiddenError) Unwrap() error        { return e.ErrorResponse }
+func (e *ForbiddenError) Is(target error) bool { return target == ErrForbidden }
+
+// NotFoundError is returned on 404 Not Found responses.
+type NotFoundError struct{ *ErrorResponse }
+
+func (e *NotFoundError) Unwrap() error        { return e.ErrorResponse }
+func (e *NotFoundError) Is(target error) bool { return target == ErrNotFound }
+
+// ConflictError is returned on 409 Conflict responses.
+type ConflictError struct{ *ErrorResponse }
+
+func (e *ConflictError) Unwrap() error        { return e.ErrorResponse }
+func (e *ConflictError) Is(target error) bool { return target == ErrConflict }
+
+// RateLimitError is returned on 429 Too Many Requests responses.
+type RateLimitError struct {
+	*ErrorResponse
+	// RetryAfter is the delay requested by the Retry-After header, if any.
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Unwrap() error        { return e.ErrorResponse }
+func (e *RateLimitError) Is(target error) bool { return target == ErrRateLimited }
+
+// ValidationError is returned on 400 Bad Request and 422 Unprocessable Entity responses.
+// The per-field errors are listed in Errors.
+type ValidationError struct{ *ErrorResponse }
+
+func (e *ValidationError) Unwrap() error        { return e.ErrorResponse }
+func (e *ValidationError) Is(target error) bool { return target == ErrValidation }
+
+func checkResponse(r *http.Response) error {
+	if c := r.StatusCode; 200 <= c && c <= 299 {
+		return nil
+	}
+
+	errorResponse := &ErrorResponse{Response: r}
+	for _, h := range requestIDHeaders {
+		if id := r.Header.Get(h); id != "" {
+			errorResponse.RequestID = id
+			break
+		}
+	}
+
+	data, err := ioutil.ReadAll(r.Body)
+	r.Body.Close()
+	if err == nil && len(data) > 0 {
+		errorResponse.Body = data
+		if err := json.Unmarshal(data, errorResponse); err != nil {
+			errorResponse.Message = strings.TrimSpace(string(data))
+		}
+	}
+	r.Body = ioutil.NopCloser(bytes.NewReader(data))
+	if errorResponse.Message == "" {
+		errorResponse.Message = http.StatusText(r.StatusCode)
+	}
+
+	switch r.StatusCode {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return &ValidationError{errorResponse}
+	case http.StatusUnauthorized:
+		return &UnauthorizedError{errorResponse}
+	case http.StatusForbidden:
+		return &ForbiddenError{errorResponse}
+	case http.StatusNotFound:
+		return &NotFoundError{errorResponse}
+	case http.StatusConflict:
+		return &ConflictError{errorResponse}
+	case http.StatusTooManyRequests:
+		d, _ := retryAfter(r.Header.Get("Retry-After"))
+		return &RateLimitError{ErrorResponse: errorResponse, RetryAfter: d}
+	}
+	return errorResponse
+}
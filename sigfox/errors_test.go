@@ -0,0 +1,104 @@
+package sigfox
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCheckResponse(t *testing.T) {
+	tests := []struct {
+		status int
+		target error
+	}{
+		{http.StatusBadRequest, ErrValidation},
+		{http.StatusUnauthorized, ErrUnauthorized},
+		{http.StatusForbidden, ErrForbidden},
+		{http.StatusNotFound, ErrNotFound},
+		{http.StatusConflict, ErrConflict},
+		{http.StatusTooManyRequests, ErrRateLimited},
+	}
+
+	for _, tt := range tests {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Request-Id", "req-1")
+			w.WriteHeader(tt.status)
+			w.Write([]byte(`{"message":"failure"}`))
+		}))
+
+		c, _ := NewClient("LOGIN_ID", "PASSWORD", WithBaseURL(server.URL))
+		c.RetryPolicy = nil
+		ctx := context.Background()
+		req, _ := c.newRequest(ctx, "GET", "/foo", nil)
+		_, err := c.Do(ctx, req)
+		server.Close()
+
+		if !errors.Is(err, tt.target) {
+			t.Errorf("status %d: error %v is not %v", tt.status, err, tt.target)
+		}
+
+		var errResp *ErrorResponse
+		if !errors.As(err, &errResp) {
+			t.Fatalf("status %d: error %v is not an *ErrorResponse", tt.status, err)
+		}
+		if got, want := errResp.Message, "failure"; got != want {
+			t.Errorf("status %d: Message is %q, want %q", tt.status, got, want)
+		}
+		if got, want := errResp.RequestID, "req-1"; got != want {
+			t.Errorf("status %d: RequestID is %q, want %q", tt.status, got, want)
+		}
+	}
+}
+
+func TestCheckResponse_fieldErrors(t *testing.T) {
+	bodies := []string{
+		`{"message":"invalid","errors":[{"field":"name","message":"required"}]}`,
+		`{"message":"invalid","errors":{"name":["required"]}}`,
+	}
+
+	for _, body := range bodies {
+		r := &http.Response{
+			StatusCode: http.StatusBadRequest,
+			Header:     http.Header{},
+			Body:       ioNopCloser(body),
+			Request:    &http.Request{Method: "POST"},
+		}
+
+		var verr *ValidationError
+		if err := checkResponse(r); !errors.As(err, &verr) {
+			t.Fatalf("checkResponse(%s) returned %v, want a *ValidationError", body, err)
+		}
+		if got, want := verr.Errors.String(), "name: required"; got != want {
+			t.Errorf("checkResponse(%s) field errors are %q, want %q", body, got, want)
+		}
+		if got := string(verr.Body); got != body {
+			t.Errorf("checkResponse(%s) Body is %q", body, got)
+		}
+	}
+}
+
+func TestCheckResponse_notJSON(t *testing.T) {
+	r := &http.Response{
+		StatusCode: http.StatusBadGateway,
+		Header:     http.Header{},
+		Body:       ioNopCloser("upstream unavailable\n"),
+		Request:    &http.Request{Method: "GET"},
+	}
+
+	var errResp *ErrorResponse
+	if err := checkResponse(r); !errors.As(err, &errResp) {
+		t.Fatalf("checkResponse returned %v, want an *ErrorResponse", err)
+	}
+	if got, want := errResp.Message, "upstream unavailable"; got != want {
+		t.Errorf("Message is %q, want %q", got, want)
+	}
+}
+
+func ioNopCloser(s string) io.ReadCloser {
+	return ioutil.NopCloser(strings.NewReader(s))
+}
@@ -0,0 +1,103 @@
+package sigfox
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+// GroupNode is a group along with its sub-groups.
+type GroupNode struct {
+	Group
+	Children []*GroupNode
+}
+
+// Walk calls fn for the node and each of its descendants, depth first,
+// parents before their children. Depth is 0 for the node Walk is called on.
+// The walk stops at the first error returned by fn.
+func (n *GroupNode) Walk(fn func(node *GroupNode, depth int) error) error {
+	return n.walk(fn, 0)
+}
+
+func (n *GroupNode) walk(fn func(node *GroupNode, depth int) error, depth int) error {
+	if err := fn(n, depth); err != nil {
+		return err
+	}
+	for _, child := range n.Children {
+		if err := child.walk(fn, depth+1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Find returns the node of the group with the given ID, or nil if it is not in the tree.
+func (n *GroupNode) Find(groupID string) *GroupNode {
+	if n.ID == groupID {
+		return n
+	}
+	for _, child := range n.Children {
+		if found := child.Find(groupID); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// Tree retrieve the hierarchy of groups under the given group.
+func (s *GroupService) Tree(rootID string) (*GroupNode, error) {
+	return s.TreeContext(context.Background(), rootID)
+}
+
+// TreeContext retrieve the hierarchy of groups under the given group with context.
+// The descendants are listed at once and placed under the last group of their path.
+// Children are sorted by name so that two trees of the same structure compare equal.
+func (s *GroupService) TreeContext(ctx context.Context, rootID string) (*GroupNode, error) {
+	root, _, err := s.InfoWithResponseContext(ctx, rootID)
+	if err != nil {
+		return nil, err
+	}
+
+	tree := &GroupNode{Group: *root}
+	nodes := map[string]*GroupNode{tree.ID: tree}
+	var descendants []*GroupNode
+
+	it := s.Iterate(ctx, &ListGroupsOptions{ParentID: []string{rootID}, Deep: true}, nil)
+	for it.Next() {
+		node := &GroupNode{Group: it.Value()}
+		if node.ID == tree.ID {
+			continue
+		}
+		nodes[node.ID] = node
+		descendants = append(descendants, node)
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, node := range descendants {
+		if len(node.Path) == 0 {
+			return nil, errors.Errorf("group %s has no path", node.ID)
+		}
+		parentID := node.Path[len(node.Path)-1].ID
+		parent, ok := nodes[parentID]
+		if !ok {
+			return nil, errors.Errorf("parent %s of group %s is not under group %s", parentID, node.ID, rootID)
+		}
+		parent.Children = append(parent.Children, node)
+	}
+
+	tree.Walk(func(node *GroupNode, depth int) error {
+		sort.Slice(node.Children, func(i, j int) bool {
+			a, b := node.Children[i], node.Children[j]
+			if a.Name != b.Name {
+				return a.Name < b.Name
+			}
+			return a.ID < b.ID
+		})
+		return nil
+	})
+
+	return tree, nil
+}
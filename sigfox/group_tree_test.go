@@ -0,0 +1,55 @@
+package sigfox
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestGroupTree(t *testing.T) {
+	var listings int
+	mux := http.NewServeMux()
+	mux.HandleFunc("/groups/root", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":"root","name":"Root"}`)
+	})
+	mux.HandleFunc("/groups", func(w http.ResponseWriter, r *http.Request) {
+		listings++
+		q := r.URL.Query()
+		if q.Get("parentId") != "root" || q.Get("deep") != "true" {
+			t.Errorf("unexpected listing query %q", r.URL.RawQuery)
+		}
+		fmt.Fprint(w, `{"data":[`+
+			`{"id":"a1","name":"A1","path":[{"id":"root"},{"id":"a"}]},`+
+			`{"id":"b","name":"B","path":[{"id":"root"}]},`+
+			`{"id":"a","name":"A","path":[{"id":"root"}]}`+
+			`],"paging":{}}`)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	c, _ := NewClient("LOGIN_ID", "PASSWORD", WithBaseURL(server.URL))
+	tree, err := c.Group.TreeContext(context.Background(), "root")
+	if err != nil {
+		t.Fatalf("TreeContext returned error: %v", err)
+	}
+
+	var lines []string
+	tree.Walk(func(node *GroupNode, depth int) error {
+		lines = append(lines, strings.Repeat(" ", depth)+node.Name)
+		return nil
+	})
+	if got, want := strings.Join(lines, "|"), "Root| A|  A1| B"; got != want {
+		t.Errorf("Tree is %q, want %q", got, want)
+	}
+
+	if listings != 1 {
+		t.Errorf("TreeContext requested %d listings, want 1", listings)
+	}
+
+	if node := tree.Find("a1"); node == nil || node.Name != "A1" {
+		t.Errorf("Find(%q) returned %v", "a1", node)
+	}
+}
@@ -8,15 +8,15 @@ import (
 type GroupService service
 
 type ListGroupsOptions struct {
-	ParentID []string `url:"parentId,omitempty"`
-	Deep     bool     `url:"deep,omitempty"`
-	Name     string   `url:"name,omitempty"`
-	Types    []int32  `url:"types,omitempty"`
-	Fields   []string `url:"fields,omitempty"`
-	Sort     string   `url:"sort,omitempty"`
-	Limit    int32    `url:"limit,omitempty"`
-	Offset   int32    `url:"offset,omitempty"`
-	PageID   string   `url:"pageId,omitempty"`
+	ParentID []string   `url:"parentId,omitempty"`
+	Deep     bool       `url:"deep,omitempty"`
+	Name     string     `url:"name,omitempty"`
+	Types    GroupTypes `url:"types,omitempty"`
+	Fields   []string   `url:"fields,omitempty"`
+	Sort     string     `url:"sort,omitempty"`
+	Limit    int32      `url:"limit,omitempty"`
+	Offset   int32      `url:"offset,omitempty"`
+	PageID   string     `url:"pageId,omitempty"`
 }
 
 type ListGroupsOutput struct {
@@ -25,37 +25,189 @@ type ListGroupsOutput struct {
 }
 
 type Group struct {
+	Name        string    `json:"name,omitempty"`
+	Description string    `json:"description,omitempty"`
+	Type        GroupType `json:"type,omitempty"`
+	Timezone    string    `json:"timezone,omitempty"`
+	ID          string    `json:"id,omitempty"`
+	NameCl      string    `json:"nameCl,omitempty"`
+	// Path lists the ancestors of the group, from the root to its parent.
+	Path              []MinimalGroup `json:"path,omitempty"`
+	NetworkOperatorID string         `json:"networkOperatorId,omitempty"`
+}
+
+// ListWithResponse retrieve a list of groups according to visibility permissions and request filters.
+func (s *GroupService) ListWithResponse(opt *ListGroupsOptions) (*ListGroupsOutput, *Response, error) {
+	return s.ListWithResponseContext(context.Background(), opt)
+}
+
+// ListWithResponseContext retrieve a list of groups according to visibility permissions and request filters with context.
+func (s *GroupService) ListWithResponseContext(ctx context.Context, opt *ListGroupsOptions) (*ListGroupsOutput, *Response, error) {
+	spath, err := addOptions("/groups", opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var out ListGroupsOutput
+	res, err := s.client.call(ctx, "GET", spath, nil, &out)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return &out, res, nil
+}
+
+// InfoWithResponse retrieve information about a group.
+func (s *GroupService) InfoWithResponse(groupID string, params ...QueryParam) (*Group, *Response, error) {
+	return s.InfoWithResponseContext(context.Background(), groupID, params...)
+}
+
+// InfoWithResponseContext retrieve information about a group with context.
+func (s *GroupService) InfoWithResponseContext(ctx context.Context, groupID string, params ...QueryParam) (*Group, *Response, error) {
+	opt := &QueryParams{}
+	for _, param := range params {
+		param(opt)
+	}
+
+	spath := fmt.Sprintf("/groups/%s", groupID)
+	spath, err := addOptions(spath, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var out Group
+	res, err := s.client.call(ctx, "GET", spath, nil, &out)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return &out, res, nil
+}
+
+type CreateGroupInput struct {
+	Name        string    `json:"name"`
+	Description string    `json:"description,omitempty"`
+	Type        GroupType `json:"type"`
+	Timezone    string    `json:"timezone,omitempty"`
+	ParentID    string    `json:"parentId"`
+}
+
+// Validate checks the values of the input.
+func (input *CreateGroupInput) Validate() error {
+	var errs fieldErrors
+	if !input.Type.Valid() {
+		errs.add("type", "unknown group type %v", input.Type)
+	}
+	return errs.err()
+}
+
+type CreateGroupOutput struct {
+	ID string `json:"id,omitempty"`
+}
+
+// CreateWithResponse create a new group.
+func (s *GroupService) CreateWithResponse(input *CreateGroupInput) (*CreateGroupOutput, *Response, error) {
+	return s.CreateWithResponseContext(context.Background(), input)
+}
+
+// CreateWithResponseContext create a new group with context.
+func (s *GroupService) CreateWithResponseContext(ctx context.Context, input *CreateGroupInput) (*CreateGroupOutput, *Response, error) {
+	if err := input.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	var out CreateGroupOutput
+	res, err := s.client.call(ctx, "POST", "/groups", input, &out)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return &out, res, nil
+}
+
+type UpdateGroupInput struct {
 	Name        string `json:"name,omitempty"`
 	Description string `json:"description,omitempty"`
-	Type        int32  `json:"type,omitempty"`
 	Timezone    string `json:"timezone,omitempty"`
-	ID          string `json:"id,omitempty"`
-	NameCl      string `json:"nameCl,omitempty"`
-	//Path
-	NetworkOperatorID string `json:"networkOperatorId,omitempty"`
 }
 
-func (s *GroupService) List(ctx context.Context, opt *ListGroupsOptions) (*ListGroupsOutput, error) {
-	spath := fmt.Sprintf("/groups")
+// UpdateWithResponse update a group.
+func (s *GroupService) UpdateWithResponse(groupID string, input *UpdateGroupInput) (*Response, error) {
+	return s.UpdateWithResponseContext(context.Background(), groupID, input)
+}
+
+// UpdateWithResponseContext update a group with context.
+func (s *GroupService) UpdateWithResponseContext(ctx context.Context, groupID string, input *UpdateGroupInput) (*Response, error) {
+	spath := fmt.Sprintf("/groups/%s", groupID)
+	return s.client.call(ctx, "PUT", spath, input, nil)
+}
+
+// DeleteWithResponse delete a group.
+func (s *GroupService) DeleteWithResponse(groupID string) (*Response, error) {
+	return s.DeleteWithResponseContext(context.Background(), groupID)
+}
+
+// DeleteWithResponseContext delete a group with context.
+func (s *GroupService) DeleteWithResponseContext(ctx context.Context, groupID string) (*Response, error) {
+	spath := fmt.Sprintf("/groups/%s", groupID)
+	return s.client.call(ctx, "DELETE", spath, nil, nil)
+}
+
+// ListUndeliveredCallbacksWithResponse retrieve a list of undelivered callback messages for a given group.
+func (s *GroupService) ListUndeliveredCallbacksWithResponse(groupID string, opt *UndeliveredCallbacksOptions) (*UndeliveredCallbacks, *Response, error) {
+	return s.ListUndeliveredCallbacksWithResponseContext(context.Background(), groupID, opt)
+}
+
+// ListUndeliveredCallbacksWithResponseContext retrieve a list of undelivered callback messages for a given group with context.
+func (s *GroupService) ListUndeliveredCallbacksWithResponseContext(ctx context.Context, groupID string, opt *UndeliveredCallbacksOptions) (*UndeliveredCallbacks, *Response, error) {
+	spath := fmt.Sprintf("/groups/%s/callbacks-not-delivered", groupID)
 	spath, err := addOptions(spath, opt)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	req, err := s.client.newRequest(ctx, "GET", spath, nil)
+	var out UndeliveredCallbacks
+	res, err := s.client.call(ctx, "GET", spath, nil, &out)
 	if err != nil {
-		return nil, err
+		return nil, res, err
 	}
 
-	res, err := s.client.Do(ctx, req)
+	return &out, res, nil
+}
+
+type ListGeolocPayloadsOptions struct {
+	Limit  int32 `url:"limit,omitempty"`
+	Offset int32 `url:"offset,omitempty"`
+}
+
+type ListGeolocPayloadsOutput struct {
+	Data   []GeolocPayload `json:"data"`
+	Paging Pagination      `json:"paging"`
+}
+
+type GeolocPayload struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+// ListGeolocPayloadsWithResponse retrieve a list of geolocation payload configurations available for a given group.
+func (s *GroupService) ListGeolocPayloadsWithResponse(groupID string, opt *ListGeolocPayloadsOptions) (*ListGeolocPayloadsOutput, *Response, error) {
+	return s.ListGeolocPayloadsWithResponseContext(context.Background(), groupID, opt)
+}
+
+// ListGeolocPayloadsWithResponseContext retrieve a list of geolocation payload configurations available for a given group with context.
+func (s *GroupService) ListGeolocPayloadsWithResponseContext(ctx context.Context, groupID string, opt *ListGeolocPayloadsOptions) (*ListGeolocPayloadsOutput, *Response, error) {
+	spath := fmt.Sprintf("/groups/%s/geoloc-payloads", groupID)
+	spath, err := addOptions(spath, opt)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	var out ListGroupsOutput
-	if err := decodeBody(res, &out); err != nil {
-		return nil, err
+	var out ListGeolocPayloadsOutput
+	res, err := s.client.call(ctx, "GET", spath, nil, &out)
+	if err != nil {
+		return nil, res, err
 	}
 
-	return &out, nil
+	return &out, res, nil
 }
@@ -0,0 +1,104 @@
+package sigfox
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// JobType identifies the kind of asynchronous bulk operation a job was started by.
+type JobType string
+
+const (
+	JobCreate      JobType = "create"
+	JobTransfer    JobType = "transfer"
+	JobReplace     JobType = "replace"
+	JobUnsubscribe JobType = "unsubscribe"
+	JobRestart     JobType = "restart"
+)
+
+// DefaultJobPollInterval is the delay between two status requests of WaitJob
+// when no interval is given.
+const DefaultJobPollInterval = 5 * time.Second
+
+func (t JobType) path(jobID string) (string, error) {
+	switch t {
+	case JobCreate:
+		return fmt.Sprintf("/devices/bulk/%s", jobID), nil
+	case JobTransfer, JobReplace, JobUnsubscribe, JobRestart:
+		return fmt.Sprintf("/devices/bulk/%s/%s", t, jobID), nil
+	}
+	return "", errors.Errorf("unknown job type: %q", string(t))
+}
+
+type JobStatus struct {
+	JobDone bool          `json:"jobDone"`
+	Total   int32         `json:"total"`
+	Status  JobStatusInfo `json:"status"`
+}
+
+type JobStatusInfo struct {
+	Success int32      `json:"success"`
+	Errors  []JobError `json:"errors,omitempty"`
+}
+
+// JobError reports why the operation failed for one device.
+type JobError struct {
+	ID      string `json:"id"`
+	Message string `json:"message"`
+}
+
+// FailedIDs returns the IDs of the devices the operation failed for,
+// so that only those can be retried.
+func (j *JobStatus) FailedIDs() []string {
+	ids := make([]string, 0, len(j.Status.Errors))
+	for _, e := range j.Status.Errors {
+		ids = append(ids, e.ID)
+	}
+	return ids
+}
+
+// JobStatusWithResponse retrieve the status of an asynchronous bulk job.
+func (s *DeviceService) JobStatusWithResponse(jobType JobType, jobID string) (*JobStatus, *Response, error) {
+	return s.JobStatusWithResponseContext(context.Background(), jobType, jobID)
+}
+
+// JobStatusWithResponseContext retrieve the status of an asynchronous bulk job with context.
+func (s *DeviceService) JobStatusWithResponseContext(ctx context.Context, jobType JobType, jobID string) (*JobStatus, *Response, error) {
+	spath, err := jobType.path(jobID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var out JobStatus
+	res, err := s.client.call(ctx, "GET", spath, nil, &out)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return &out, res, nil
+}
+
+// WaitJob polls the status of an asynchronous bulk job every interval until it is done
+// or the context is done. A zero interval means DefaultJobPollInterval.
+func (s *DeviceService) WaitJob(ctx context.Context, jobType JobType, jobID string, interval time.Duration) (*JobStatus, error) {
+	if interval <= 0 {
+		interval = DefaultJobPollInterval
+	}
+
+	for {
+		status, _, err := s.JobStatusWithResponseContext(ctx, jobType, jobID)
+		if err != nil {
+			return nil, err
+		}
+		if status.JobDone {
+			return status, nil
+		}
+
+		if err := sleepContext(ctx, interval); err != nil {
+			return status, err
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package sigfox
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestWaitJob(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.URL.Path, "/devices/bulk/transfer/job1"; got != want {
+			t.Errorf("request path is %q, want %q", got, want)
+		}
+		calls++
+		if calls < 2 {
+			fmt.Fprint(w, `{"jobDone":false,"total":3}`)
+			return
+		}
+		fmt.Fprint(w, `{"jobDone":true,"total":3,"status":{"success":1,"errors":[{"id":"A","message":"x"},{"id":"B","message":"y"}]}}`)
+	}))
+	defer server.Close()
+
+	c, _ := NewClient("LOGIN_ID", "PASSWORD", WithBaseURL(server.URL))
+	status, err := c.Device.WaitJob(context.Background(), JobTransfer, "job1", time.Millisecond)
+	if err != nil {
+		t.Fatalf("WaitJob returned error: %v", err)
+	}
+
+	if got, want := fmt.Sprint(status.FailedIDs()), "[A B]"; got != want {
+		t.Errorf("FailedIDs is %v, want %v", got, want)
+	}
+	if calls != 2 {
+		t.Errorf("WaitJob sent %d requests, want 2", calls)
+	}
+}
+
+func TestWaitJob_deadline(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"jobDone":false}`)
+	}))
+	defer server.Close()
+
+	c, _ := NewClient("LOGIN_ID", "PASSWORD", WithBaseURL(server.URL))
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if _, err := c.Device.WaitJob(ctx, JobCreate, "job1", 5*time.Millisecond); err != context.DeadlineExceeded {
+		t.Errorf("WaitJob returned %v, want %v", err, context.DeadlineExceeded)
+	}
+}
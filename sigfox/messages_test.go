@@ -0,0 +1,64 @@
+package sigfox
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestMessages_locationAndDownlink(t *testing.T) {
+	const messages = `{"data":[{"device":{"id":"d1"},"time":1600000000000,"seqNumber":7,
+		"computedLocation":[{"lat":43.5,"lng":1.25,"radius":1200,"source":2,"status":1,"placeIds":["p1"]}],
+		"downlinkAnswerStatus":{"baseStation":{"id":"0A1B","name":"BS"},"plannedPower":14,"data":"0102030405060708","operator":"SIGFOX_France","country":"FRA"}},
+		{"device":{"id":"d1"},"time":1600000001000,"seqNumber":8,"computedLocation":{"lat":1,"lng":2,"radius":3,"source":6,"status":1}}],"paging":{}}`
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/devices/d1/messages", func(w http.ResponseWriter, r *http.Request) { fmt.Fprint(w, messages) })
+	mux.HandleFunc("/device-types/t1/messages", func(w http.ResponseWriter, r *http.Request) { fmt.Fprint(w, messages) })
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	c, _ := NewClient("LOGIN_ID", "PASSWORD", WithBaseURL(server.URL))
+	ctx := context.Background()
+
+	deviceMessages, _, err := c.Device.MessagesWithResponseContext(ctx, "d1")
+	if err != nil {
+		t.Fatalf("MessagesContext returned error: %v", err)
+	}
+	typeMessages, _, err := c.DeviceType.ListMessagesWithResponseContext(ctx, "t1")
+	if err != nil {
+		t.Fatalf("ListMessagesContext returned error: %v", err)
+	}
+
+	wantLocations := []ComputedLocations{
+		{{Lat: 43.5, Lng: 1.25, Radius: 1200, Source: 2, Status: 1, PlaceIds: []string{"p1"}}},
+		{{Lat: 1, Lng: 2, Radius: 3, Source: 6, Status: 1}},
+	}
+	wantStatus := &DownlinkAnswerStatus{
+		BaseStation:  MinBaseStation{ID: "0A1B", Name: "BS"},
+		PlannedPower: 14,
+		Data:         "0102030405060708",
+		Operator:     "SIGFOX_France",
+		Country:      "FRA",
+	}
+
+	for name, data := range map[string][]Message{"Device.Messages": deviceMessages.Data, "DeviceType.ListMessages": typeMessages.Data} {
+		if len(data) != 2 {
+			t.Fatalf("%s returned %d messages, want 2", name, len(data))
+		}
+		for i, m := range data {
+			if !reflect.DeepEqual(m.ComputedLocation, wantLocations[i]) {
+				t.Errorf("%s: message %d has locations %+v, want %+v", name, i, m.ComputedLocation, wantLocations[i])
+			}
+		}
+		if !reflect.DeepEqual(data[0].DownlinkAnswerStatus, wantStatus) {
+			t.Errorf("%s: downlink answer status is %+v, want %+v", name, data[0].DownlinkAnswerStatus, wantStatus)
+		}
+		if data[1].DownlinkAnswerStatus != nil {
+			t.Errorf("%s: message without downlink has status %+v", name, data[1].DownlinkAnswerStatus)
+		}
+	}
+}
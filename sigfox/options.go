@@ -0,0 +1,152 @@
+package sigfox
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Logger is the interface used by the client to report requests and retries.
+// *log.Logger satisfies it.
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+// Middleware wraps the transport used to send the requests.
+type Middleware func(http.RoundTripper) http.RoundTripper
+
+type clientOptions struct {
+	baseURL    string
+	httpClient *http.Client
+	userAgent  string
+	logger     Logger
+	timeout    time.Duration
+	middleware []Middleware
+
+	requestHooks  []RequestHook
+	responseHooks []ResponseHook
+}
+
+// ClientOption configures a Client created by NewClient.
+type ClientOption func(*clientOptions) error
+
+// WithBaseURL sets the API endpoint, e.g. a staging proxy or a local fake.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(o *clientOptions) error {
+		if len(baseURL) == 0 {
+			return errors.New("missing base url")
+		}
+		o.baseURL = baseURL
+		return nil
+	}
+}
+
+// WithHTTPClient sets the HTTP client used to send the requests.
+// The client is copied, so later options never alter the one given.
+func WithHTTPClient(hc *http.Client) ClientOption {
+	return func(o *clientOptions) error {
+		if hc == nil {
+			return errors.New("nil http client")
+		}
+		o.httpClient = hc
+		return nil
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(ua string) ClientOption {
+	return func(o *clientOptions) error {
+		if len(ua) == 0 {
+			return errors.New("missing user agent")
+		}
+		o.userAgent = ua
+		return nil
+	}
+}
+
+// WithLogger sets the logger reporting requests and retries.
+func WithLogger(l Logger) ClientOption {
+	return func(o *clientOptions) error {
+		if l == nil {
+			return errors.New("nil logger")
+		}
+		o.logger = l
+		return nil
+	}
+}
+
+// WithTimeout sets the time limit of each HTTP request.
+func WithTimeout(d time.Duration) ClientOption {
+	return func(o *clientOptions) error {
+		if d <= 0 {
+			return errors.Errorf("invalid timeout: %v", d)
+		}
+		o.timeout = d
+		return nil
+	}
+}
+
+// WithTransport wraps the transport of the HTTP client with middleware.
+// Middlewares are applied in order, the last one being the outermost.
+func WithTransport(m Middleware) ClientOption {
+	return func(o *clientOptions) error {
+		if m == nil {
+			return errors.New("nil transport middleware")
+		}
+		o.middleware = append(o.middleware, m)
+		return nil
+	}
+}
+
+// WithRequestHook registers a hook called before each attempt of a request is sent.
+func WithRequestHook(h RequestHook) ClientOption {
+	return func(o *clientOptions) error {
+		if h == nil {
+			return errors.New("nil request hook")
+		}
+		o.requestHooks = append(o.requestHooks, h)
+		return nil
+	}
+}
+
+// WithResponseHook registers a hook called after each attempt of a request.
+func WithResponseHook(h ResponseHook) ClientOption {
+	return func(o *clientOptions) error {
+		if h == nil {
+			return errors.New("nil response hook")
+		}
+		o.responseHooks = append(o.responseHooks, h)
+		return nil
+	}
+}
+
+func (o *clientOptions) buildHTTPClient() *http.Client {
+	hc := &http.Client{}
+	if o.httpClient != nil {
+		*hc = *o.httpClient
+	}
+
+	if o.timeout > 0 {
+		hc.Timeout = o.timeout
+	}
+
+	if len(o.middleware) > 0 {
+		rt := hc.Transport
+		if rt == nil {
+			rt = http.DefaultTransport
+		}
+		for _, m := range o.middleware {
+			rt = m(rt)
+		}
+		hc.Transport = rt
+	}
+
+	return hc
+}
+
+func (c *Client) logf(format string, v ...interface{}) {
+	if c.Logger != nil {
+		c.Logger.Printf(format, v...)
+	}
+}
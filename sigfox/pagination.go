@@ -0,0 +1,505 @@
+package sigfox
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// IterOptions configures how an iterator walks through a paginated listing.
+type IterOptions struct {
+	// MaxItems stops the iteration once this many items have been yielded.
+	// Zero means no limit.
+	MaxItems int
+	// Cursor resumes the iteration from a value previously returned by Cursor.
+	Cursor string
+}
+
+// CursorDone is the cursor of an exhausted iteration. Resuming from it
+// yields no item.
+const CursorDone = "#done"
+
+// fetchFunc requests the page at spath, keeps its items and returns
+// how many it holds along with the paging.next URL of the response.
+type fetchFunc func(ctx context.Context, spath string) (int, string, error)
+
+// iter holds the state shared by every typed iterator.
+// The pages are requested lazily by following the paging.next URL
+// returned by the API, whether it is built with offset or pageId.
+type iter struct {
+	ctx    context.Context
+	client *Client
+	fetch  fetchFunc
+
+	max   int
+	count int
+
+	// cur is the path of the page being read and pos the index of the
+	// next item to read within it.
+	cur  string
+	next string
+	pos  int
+	size int
+	skip int
+
+	started bool
+	done    bool
+	err     error
+}
+
+func (it *iter) init(ctx context.Context, c *Client, spath string, iopt *IterOptions, fetch fetchFunc) {
+	it.ctx = ctx
+	it.client = c
+	it.fetch = fetch
+	it.next = spath
+
+	if iopt == nil {
+		return
+	}
+	it.max = iopt.MaxItems
+
+	if iopt.Cursor == CursorDone {
+		it.done = true
+		return
+	}
+	if iopt.Cursor != "" {
+		u, err := url.Parse(iopt.Cursor)
+		if err != nil {
+			it.err = err
+			return
+		}
+		if u.Fragment != "" {
+			skip, err := strconv.Atoi(u.Fragment)
+			if err != nil {
+				it.err = err
+				return
+			}
+			it.skip = skip
+		}
+		u.Fragment = ""
+		it.next = u.String()
+	}
+}
+
+func (it *iter) advance() bool {
+	if it.err != nil || it.done {
+		return false
+	}
+	if it.max > 0 && it.count >= it.max {
+		return false
+	}
+	if err := it.ctx.Err(); err != nil {
+		it.err = err
+		return false
+	}
+
+	for it.pos >= it.size {
+		if it.started && it.next == "" {
+			return false
+		}
+		if !it.nextPage() {
+			return false
+		}
+	}
+
+	it.pos++
+	it.count++
+	return true
+}
+
+func (it *iter) nextPage() bool {
+	if err := it.ctx.Err(); err != nil {
+		it.err = err
+		return false
+	}
+
+	size, next, err := it.fetch(it.ctx, it.next)
+	if err != nil {
+		it.err = err
+		return false
+	}
+
+	it.cur = it.next
+	it.next, err = it.client.pagePath(next)
+	if err != nil {
+		it.err = err
+		return false
+	}
+	it.size = size
+	it.pos = 0
+	it.started = true
+
+	if it.skip > 0 {
+		it.pos = it.skip
+		it.skip = 0
+	}
+	return true
+}
+
+// Err returns the error, if any, that stopped the iteration.
+func (it *iter) Err() error {
+	return it.err
+}
+
+// Cursor returns an opaque value which can be passed to IterOptions
+// to resume the iteration right after the last item yielded.
+// It returns CursorDone once the listing is exhausted.
+func (it *iter) Cursor() string {
+	if it.done {
+		return CursorDone
+	}
+	if !it.started {
+		if it.skip > 0 {
+			return it.next + "#" + strconv.Itoa(it.skip)
+		}
+		return it.next
+	}
+	if it.pos < it.size {
+		return it.cur + "#" + strconv.Itoa(it.pos)
+	}
+	if it.next == "" {
+		return CursorDone
+	}
+	return it.next
+}
+
+// pagePath converts the absolute paging.next URL returned by the API
+// into a path relative to the client base URL.
+func (c *Client) pagePath(next string) (string, error) {
+	if next == "" {
+		return "", nil
+	}
+
+	u, err := url.Parse(next)
+	if err != nil {
+		return "", err
+	}
+
+	spath := u.Path
+	if base := strings.TrimSuffix(c.baseURL.Path, "/"); base != "" && strings.HasPrefix(spath, base+"/") {
+		spath = strings.TrimPrefix(spath, base)
+	}
+	if u.RawQuery != "" {
+		spath += "?" + u.RawQuery
+	}
+
+	return spath, nil
+}
+
+// DeviceIterator iterates over the devices of a listing.
+type DeviceIterator struct {
+	iter
+	page []Device
+}
+
+// Next advances to the next device, requesting a new page when needed.
+func (it *DeviceIterator) Next() bool { return it.advance() }
+
+// Value returns the current device. It is only valid after Next returned true.
+func (it *DeviceIterator) Value() Device { return it.page[it.pos-1] }
+
+// Iterate returns an iterator over all the devices matching the options.
+func (s *DeviceService) Iterate(ctx context.Context, opt *DeviceListOptions, iopt *IterOptions) *DeviceIterator {
+	it := &DeviceIterator{}
+	spath, err := addOptions("/devices", opt)
+	it.init(ctx, s.client, spath, iopt, func(ctx context.Context, spath string) (int, string, error) {
+		var out ListDevices
+		if _, err := s.client.call(ctx, "GET", spath, nil, &out); err != nil {
+			return 0, "", err
+		}
+		it.page = out.Data
+		return len(out.Data), out.Paging.Next, nil
+	})
+	if err != nil {
+		it.err = err
+	}
+	return it
+}
+
+// IterateDevices returns an iterator over all the devices holding a token of a contract.
+func (s *ContractService) IterateDevices(ctx context.Context, contractID string, opt *ListContractDevicesOptions, iopt *IterOptions) *DeviceIterator {
+	it := &DeviceIterator{}
+	spath, err := addOptions("/contract-infos/"+contractID+"/devices", opt)
+	it.init(ctx, s.client, spath, iopt, func(ctx context.Context, spath string) (int, string, error) {
+		var out ListDevices
+		if _, err := s.client.call(ctx, "GET", spath, nil, &out); err != nil {
+			return 0, "", err
+		}
+		it.page = out.Data
+		return len(out.Data), out.Paging.Next, nil
+	})
+	if err != nil {
+		it.err = err
+	}
+	return it
+}
+
+// MessageIterator iterates over the messages of a device or a device type.
+type MessageIterator struct {
+	iter
+	page []Message
+}
+
+// Next advances to the next message, requesting a new page when needed.
+func (it *MessageIterator) Next() bool { return it.advance() }
+
+// Value returns the current message. It is only valid after Next returned true.
+func (it *MessageIterator) Value() Message { return it.page[it.pos-1] }
+
+func newMessageIterator(ctx context.Context, c *Client, spath string, iopt *IterOptions, params []QueryParam) *MessageIterator {
+	opt := &QueryParams{}
+	for _, param := range params {
+		param(opt)
+	}
+
+	it := &MessageIterator{}
+	spath, err := addOptions(spath, opt)
+	it.init(ctx, c, spath, iopt, func(ctx context.Context, spath string) (int, string, error) {
+		var out DeviceMessages
+		if _, err := c.call(ctx, "GET", spath, nil, &out); err != nil {
+			return 0, "", err
+		}
+		it.page = out.Data
+		return len(out.Data), out.Paging.Next, nil
+	})
+	if err != nil {
+		it.err = err
+	}
+	return it
+}
+
+// IterateMessages returns an iterator over all the messages of a device.
+func (s *DeviceService) IterateMessages(ctx context.Context, deviceID string, iopt *IterOptions, params ...QueryParam) *MessageIterator {
+	return newMessageIterator(ctx, s.client, "/devices/"+deviceID+"/messages", iopt, params)
+}
+
+// IterateMessages returns an iterator over all the messages of a device type.
+func (s *DeviceTypeService) IterateMessages(ctx context.Context, deviceTypeID string, iopt *IterOptions, params ...QueryParam) *MessageIterator {
+	return newMessageIterator(ctx, s.client, "/device-types/"+deviceTypeID+"/messages", iopt, params)
+}
+
+// LocationIterator iterates over the locations of a device.
+type LocationIterator struct {
+	iter
+	page []DeviceLocation
+}
+
+// Next advances to the next location, requesting a new page when needed.
+func (it *LocationIterator) Next() bool { return it.advance() }
+
+// Value returns the current location. It is only valid after Next returned true.
+func (it *LocationIterator) Value() DeviceLocation { return it.page[it.pos-1] }
+
+// IterateLocations returns an iterator over all the locations of a device.
+func (s *DeviceService) IterateLocations(ctx context.Context, deviceID string, iopt *IterOptions, params ...QueryParam) *LocationIterator {
+	opt := &QueryParams{}
+	for _, param := range params {
+		param(opt)
+	}
+
+	it := &LocationIterator{}
+	spath, err := addOptions("/devices/"+deviceID+"/locations", opt)
+	it.init(ctx, s.client, spath, iopt, func(ctx context.Context, spath string) (int, string, error) {
+		var out DeviceLocations
+		if _, err := s.client.call(ctx, "GET", spath, nil, &out); err != nil {
+			return 0, "", err
+		}
+		it.page = out.Data
+		return len(out.Data), out.Paging.Next, nil
+	})
+	if err != nil {
+		it.err = err
+	}
+	return it
+}
+
+// DeviceTypeIterator iterates over the device types of a listing.
+type DeviceTypeIterator struct {
+	iter
+	page []DeviceType
+}
+
+// Next advances to the next device type, requesting a new page when needed.
+func (it *DeviceTypeIterator) Next() bool { return it.advance() }
+
+// Value returns the current device type. It is only valid after Next returned true.
+func (it *DeviceTypeIterator) Value() DeviceType { return it.page[it.pos-1] }
+
+// Iterate returns an iterator over all the device types matching the options.
+func (s *DeviceTypeService) Iterate(ctx context.Context, opt *ListDeviceTypesOptions, iopt *IterOptions) *DeviceTypeIterator {
+	it := &DeviceTypeIterator{}
+	spath, err := addOptions("/device-types", opt)
+	it.init(ctx, s.client, spath, iopt, func(ctx context.Context, spath string) (int, string, error) {
+		var out ListDeviceTypesOutput
+		if _, err := s.client.call(ctx, "GET", spath, nil, &out); err != nil {
+			return 0, "", err
+		}
+		it.page = out.Data
+		return len(out.Data), out.Paging.Next, nil
+	})
+	if err != nil {
+		it.err = err
+	}
+	return it
+}
+
+// GroupIterator iterates over the groups of a listing.
+type GroupIterator struct {
+	iter
+	page []Group
+}
+
+// Next advances to the next group, requesting a new page when needed.
+func (it *GroupIterator) Next() bool { return it.advance() }
+
+// Value returns the current group. It is only valid after Next returned true.
+func (it *GroupIterator) Value() Group { return it.page[it.pos-1] }
+
+// Iterate returns an iterator over all the groups matching the options.
+func (s *GroupService) Iterate(ctx context.Context, opt *ListGroupsOptions, iopt *IterOptions) *GroupIterator {
+	it := &GroupIterator{}
+	spath, err := addOptions("/groups", opt)
+	it.init(ctx, s.client, spath, iopt, func(ctx context.Context, spath string) (int, string, error) {
+		var out ListGroupsOutput
+		if _, err := s.client.call(ctx, "GET", spath, nil, &out); err != nil {
+			return 0, "", err
+		}
+		it.page = out.Data
+		return len(out.Data), out.Paging.Next, nil
+	})
+	if err != nil {
+		it.err = err
+	}
+	return it
+}
+
+// ContractIterator iterates over the contracts of a listing.
+type ContractIterator struct {
+	iter
+	page []ContractInfo
+}
+
+// Next advances to the next contract, requesting a new page when needed.
+func (it *ContractIterator) Next() bool { return it.advance() }
+
+// Value returns the current contract. It is only valid after Next returned true.
+func (it *ContractIterator) Value() ContractInfo { return it.page[it.pos-1] }
+
+// Iterate returns an iterator over all the contracts matching the options.
+func (s *ContractService) Iterate(ctx context.Context, opt *ListContractInfosOptions, iopt *IterOptions) *ContractIterator {
+	it := &ContractIterator{}
+	spath, err := addOptions("/contract-infos", opt)
+	it.init(ctx, s.client, spath, iopt, func(ctx context.Context, spath string) (int, string, error) {
+		var out ListContractInfosOutput
+		if _, err := s.client.call(ctx, "GET", spath, nil, &out); err != nil {
+			return 0, "", err
+		}
+		it.page = out.Data
+		return len(out.Data), out.Paging.Next, nil
+	})
+	if err != nil {
+		it.err = err
+	}
+	return it
+}
+
+// ProfileIterator iterates over the profiles of a listing.
+type ProfileIterator struct {
+	iter
+	page []Profile
+}
+
+// Next advances to the next profile, requesting a new page when needed.
+func (it *ProfileIterator) Next() bool { return it.advance() }
+
+// Value returns the current profile. It is only valid after Next returned true.
+func (it *ProfileIterator) Value() Profile { return it.page[it.pos-1] }
+
+// Iterate returns an iterator over all the profiles matching the input.
+func (s *ProfileService) Iterate(ctx context.Context, input *ListProfilesInput, iopt *IterOptions) *ProfileIterator {
+	it := &ProfileIterator{}
+	spath, err := addOptions("/profiles", input)
+	it.init(ctx, s.client, spath, iopt, func(ctx context.Context, spath string) (int, string, error) {
+		var out ListProfilesOutput
+		if _, err := s.client.call(ctx, "GET", spath, nil, &out); err != nil {
+			return 0, "", err
+		}
+		it.page = out.Data
+		return len(out.Data), out.Paging.Next, nil
+	})
+	if err != nil {
+		it.err = err
+	}
+	return it
+}
+
+// ApiUserIterator iterates over the API users of a listing.
+type ApiUserIterator struct {
+	iter
+	page []ApiUser
+}
+
+// Next advances to the next API user, requesting a new page when needed.
+func (it *ApiUserIterator) Next() bool { return it.advance() }
+
+// Value returns the current API user. It is only valid after Next returned true.
+func (it *ApiUserIterator) Value() ApiUser { return it.page[it.pos-1] }
+
+// Iterate returns an iterator over all the API users.
+func (s *ApiUserService) Iterate(ctx context.Context, iopt *IterOptions, params ...QueryParam) *ApiUserIterator {
+	opt := &QueryParams{}
+	for _, param := range params {
+		param(opt)
+	}
+
+	it := &ApiUserIterator{}
+	spath, err := addOptions("/api-users", opt)
+	it.init(ctx, s.client, spath, iopt, func(ctx context.Context, spath string) (int, string, error) {
+		var out ListApiUsersOutput
+		if _, err := s.client.call(ctx, "GET", spath, nil, &out); err != nil {
+			return 0, "", err
+		}
+		it.page = out.Data
+		return len(out.Data), out.Paging.Next, nil
+	})
+	if err != nil {
+		it.err = err
+	}
+	return it
+}
+
+// UserIterator iterates over the users of a listing.
+type UserIterator struct {
+	iter
+	page []User
+}
+
+// Next advances to the next user, requesting a new page when needed.
+func (it *UserIterator) Next() bool { return it.advance() }
+
+// Value returns the current user. It is only valid after Next returned true.
+func (it *UserIterator) Value() User { return it.page[it.pos-1] }
+
+// Iterate returns an iterator over all the users.
+func (s *UserService) Iterate(ctx context.Context, iopt *IterOptions, params ...QueryParam) *UserIterator {
+	opt := &QueryParams{}
+	for _, param := range params {
+		param(opt)
+	}
+
+	it := &UserIterator{}
+	spath, err := addOptions("/users", opt)
+	it.init(ctx, s.client, spath, iopt, func(ctx context.Context, spath string) (int, string, error) {
+		var out ListUsersOutput
+		if _, err := s.client.call(ctx, "GET", spath, nil, &out); err != nil {
+			return 0, "", err
+		}
+		it.page = out.Data
+		return len(out.Data), out.Paging.Next, nil
+	})
+	if err != nil {
+		it.err = err
+	}
+	return it
+}
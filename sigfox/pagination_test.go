@@ -0,0 +1,111 @@
+package sigfox
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func setupPagingServer(t *testing.T) (*Client, func()) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	mux.HandleFunc("/v2/devices", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("pageId") {
+		case "":
+			fmt.Fprintf(w, `{"data":[{"id":"1"},{"id":"2"}],"paging":{"next":"%s/v2/devices?limit=2&pageId=p2"}}`, server.URL)
+		case "p2":
+			fmt.Fprint(w, `{"data":[{"id":"3"},{"id":"4"}],"paging":{}}`)
+		default:
+			t.Errorf("unexpected pageId %q", r.URL.Query().Get("pageId"))
+		}
+	})
+
+	c, _ := NewClient("LOGIN_ID", "PASSWORD", WithBaseURL(server.URL+"/v2"))
+
+	return c, server.Close
+}
+
+func TestDeviceIterator(t *testing.T) {
+	c, teardown := setupPagingServer(t)
+	defer teardown()
+
+	it := c.Device.Iterate(context.Background(), &DeviceListOptions{Limit: 2}, nil)
+	var ids []string
+	for it.Next() {
+		ids = append(ids, it.Value().ID)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("Iterate returned error: %v", err)
+	}
+
+	if got, want := fmt.Sprint(ids), "[1 2 3 4]"; got != want {
+		t.Errorf("Iterate yielded %v, want %v", got, want)
+	}
+	if got := it.Cursor(); got != CursorDone {
+		t.Errorf("Cursor of exhausted iterator is %q, want %q", got, CursorDone)
+	}
+}
+
+func TestDeviceIterator_resumeExhausted(t *testing.T) {
+	c, teardown := setupPagingServer(t)
+	defer teardown()
+	ctx := context.Background()
+
+	it := c.Device.Iterate(ctx, nil, nil)
+	for it.Next() {
+	}
+
+	it = c.Device.Iterate(ctx, nil, &IterOptions{Cursor: it.Cursor()})
+	if it.Next() {
+		t.Errorf("resumed Iterate yielded %v, want nothing", it.Value().ID)
+	}
+	if err := it.Err(); err != nil {
+		t.Errorf("resumed Iterate returned error: %v", err)
+	}
+	if got := it.Cursor(); got != CursorDone {
+		t.Errorf("Cursor of resumed iterator is %q, want %q", got, CursorDone)
+	}
+}
+
+func TestDeviceIterator_maxItemsAndCursor(t *testing.T) {
+	c, teardown := setupPagingServer(t)
+	defer teardown()
+	ctx := context.Background()
+
+	it := c.Device.Iterate(ctx, nil, &IterOptions{MaxItems: 3})
+	var ids []string
+	for it.Next() {
+		ids = append(ids, it.Value().ID)
+	}
+	if got, want := fmt.Sprint(ids), "[1 2 3]"; got != want {
+		t.Errorf("Iterate yielded %v, want %v", got, want)
+	}
+
+	it = c.Device.Iterate(ctx, nil, &IterOptions{Cursor: it.Cursor()})
+	ids = nil
+	for it.Next() {
+		ids = append(ids, it.Value().ID)
+	}
+	if got, want := fmt.Sprint(ids), "[4]"; got != want {
+		t.Errorf("resumed Iterate yielded %v, want %v", got, want)
+	}
+}
+
+func TestDeviceIterator_canceled(t *testing.T) {
+	c, teardown := setupPagingServer(t)
+	defer teardown()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	it := c.Device.Iterate(ctx, nil, nil)
+	if it.Next() {
+		t.Error("Next returned true with a canceled context")
+	}
+	if it.Err() != context.Canceled {
+		t.Errorf("Err is %v, want %v", it.Err(), context.Canceled)
+	}
+}
@@ -0,0 +1,292 @@
+package sigfox
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// DefaultPollInterval is the time a MessagePoller waits between two polls.
+const DefaultPollInterval = time.Minute
+
+// MessageKey identifies a message for deduplication.
+type MessageKey struct {
+	Device    string    `json:"device"`
+	SeqNumber int32     `json:"seqNumber"`
+	Time      Timestamp `json:"time"`
+}
+
+// KeyOf returns the key of a message.
+func KeyOf(m *Message) MessageKey {
+	return MessageKey{Device: m.Device.ID, SeqNumber: m.SeqNumber, Time: m.Time}
+}
+
+// PollCursor is the position of a MessagePoller: the time of the last delivered message
+// and the keys of the messages delivered at that time, which the next poll returns again.
+type PollCursor struct {
+	Since Timestamp    `json:"since"`
+	Seen  []MessageKey `json:"seen,omitempty"`
+}
+
+// CursorStore persists the cursors of pollers, so that a restarted poller
+// neither loses nor replays messages.
+type CursorStore interface {
+	// Load returns the cursor saved under key, or nil if there is none.
+	Load(ctx context.Context, key string) (*PollCursor, error)
+	Save(ctx context.Context, key string, c *PollCursor) error
+}
+
+// MemoryCursorStore is a CursorStore keeping the cursors in memory.
+type MemoryCursorStore struct {
+	mu      sync.Mutex
+	cursors map[string]PollCursor
+}
+
+// NewMemoryCursorStore returns an empty store.
+func NewMemoryCursorStore() *MemoryCursorStore {
+	return &MemoryCursorStore{cursors: make(map[string]PollCursor)}
+}
+
+// Load returns the cursor saved under key.
+func (s *MemoryCursorStore) Load(ctx context.Context, key string) (*PollCursor, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.cursors[key]
+	if !ok {
+		return nil, nil
+	}
+	c.Seen = append([]MessageKey(nil), c.Seen...)
+	return &c, nil
+}
+
+// Save saves the cursor under key.
+func (s *MemoryCursorStore) Save(ctx context.Context, key string, c *PollCursor) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	saved := *c
+	saved.Seen = append([]MessageKey(nil), c.Seen...)
+	s.cursors[key] = saved
+	return nil
+}
+
+// FileCursorStore is a CursorStore keeping the cursors in a JSON file.
+// The file is replaced atomically on every save.
+type FileCursorStore struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFileCursorStore returns a store saving the cursors in the file at path.
+func NewFileCursorStore(path string) *FileCursorStore {
+	return &FileCursorStore{path: path}
+}
+
+func (s *FileCursorStore) read() (map[string]PollCursor, error) {
+	cursors := make(map[string]PollCursor)
+	b, err := ioutil.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return cursors, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, &cursors); err != nil {
+		return nil, errors.Wrapf(err, "invalid cursor file %s", s.path)
+	}
+	return cursors, nil
+}
+
+// Load returns the cursor saved under key.
+func (s *FileCursorStore) Load(ctx context.Context, key string) (*PollCursor, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cursors, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+	c, ok := cursors[key]
+	if !ok {
+		return nil, nil
+	}
+	return &c, nil
+}
+
+// Save saves the cursor under key.
+func (s *FileCursorStore) Save(ctx context.Context, key string, c *PollCursor) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cursors, err := s.read()
+	if err != nil {
+		return err
+	}
+	cursors[key] = *c
+
+	b, err := json.Marshal(cursors)
+	if err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path)
+}
+
+// PollOptions configures a MessagePoller.
+type PollOptions struct {
+	// Interval is the time waited between two polls. Defaults to DefaultPollInterval.
+	Interval time.Duration
+	// Store persists the cursor of the poller. Defaults to a MemoryCursorStore.
+	Store CursorStore
+	// Key is the key of the cursor in the store. Defaults to the path of the messages.
+	Key string
+	// Since is the time the first poll starts from when the store holds no cursor.
+	// Zero lets the API pick its default history.
+	Since time.Time
+	// Params are added to the requests, e.g. Fields or Limit.
+	Params []QueryParam
+}
+
+// MessagePoller polls the messages of a device or a device type.
+type MessagePoller struct {
+	client   *Client
+	spath    string
+	interval time.Duration
+	store    CursorStore
+	key      string
+	since    Timestamp
+	params   []QueryParam
+}
+
+func newMessagePoller(c *Client, spath string, opt *PollOptions) *MessagePoller {
+	if opt == nil {
+		opt = &PollOptions{}
+	}
+	p := &MessagePoller{
+		client:   c,
+		spath:    spath,
+		interval: opt.Interval,
+		store:    opt.Store,
+		key:      opt.Key,
+		since:    NewTimestamp(opt.Since),
+		params:   opt.Params,
+	}
+	if p.interval <= 0 {
+		p.interval = DefaultPollInterval
+	}
+	if p.store == nil {
+		p.store = NewMemoryCursorStore()
+	}
+	if p.key == "" {
+		p.key = spath
+	}
+	return p
+}
+
+// PollMessages returns a poller over the messages of a device.
+func (s *DeviceService) PollMessages(deviceID string, opt *PollOptions) *MessagePoller {
+	return newMessagePoller(s.client, "/devices/"+deviceID+"/messages", opt)
+}
+
+// PollMessages returns a poller over the messages of a device type.
+func (s *DeviceTypeService) PollMessages(deviceTypeID string, opt *PollOptions) *MessagePoller {
+	return newMessagePoller(s.client, "/device-types/"+deviceTypeID+"/messages", opt)
+}
+
+// Run polls the messages until the context is canceled or a request fails, and sends the
+// new ones to out, oldest first. Run blocks while out is full. Every poll requests the
+// messages since the time of the last delivered one and follows the pagination, dropping
+// the messages already delivered. The cursor is saved after each delivery.
+// Run returns the error of the context when it is canceled.
+func (p *MessagePoller) Run(ctx context.Context, out chan<- Message) error {
+	cursor, err := p.store.Load(ctx, p.key)
+	if err != nil {
+		return errors.Wrap(err, "cannot load the poller cursor")
+	}
+	if cursor == nil {
+		cursor = &PollCursor{Since: p.since}
+	}
+
+	for {
+		if err := p.poll(ctx, cursor, out); err != nil {
+			return err
+		}
+		if err := sleepContext(ctx, p.interval); err != nil {
+			return err
+		}
+	}
+}
+
+// poll requests the messages since the cursor and delivers the new ones.
+func (p *MessagePoller) poll(ctx context.Context, cursor *PollCursor, out chan<- Message) error {
+	seen := make(map[MessageKey]bool, len(cursor.Seen))
+	for _, k := range cursor.Seen {
+		seen[k] = true
+	}
+
+	params := p.params
+	if cursor.Since > 0 {
+		params = append(append([]QueryParam(nil), params...), Since(cursor.Since.Millis()))
+	}
+
+	var messages []Message
+	it := newMessageIterator(ctx, p.client, p.spath, nil, params)
+	for it.Next() {
+		m := it.Value()
+		k := KeyOf(&m)
+		if m.Time < cursor.Since || seen[k] {
+			continue
+		}
+		seen[k] = true
+		messages = append(messages, m)
+	}
+	if err := it.Err(); err != nil {
+		return err
+	}
+
+	// The API lists the most recent messages first.
+	for i, j := 0, len(messages)-1; i < j; i, j = i+1, j-1 {
+		messages[i], messages[j] = messages[j], messages[i]
+	}
+	sort.SliceStable(messages, func(i, j int) bool {
+		return messages[i].Time < messages[j].Time
+	})
+
+	for _, m := range messages {
+		select {
+		case out <- m:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+		if m.Time > cursor.Since {
+			cursor.Since = m.Time
+			cursor.Seen = cursor.Seen[:0]
+		}
+		cursor.Seen = append(cursor.Seen, KeyOf(&m))
+		if err := p.store.Save(ctx, p.key, cursor); err != nil {
+			return errors.Wrap(err, "cannot save the poller cursor")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package sigfox
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestMessagePoller(t *testing.T) {
+	var mu sync.Mutex
+	// messages are listed most recent first, two per page.
+	messages := []Message{
+		{Device: Device{ID: "d1"}, Time: 2000, SeqNumber: 3},
+		{Device: Device{ID: "d1"}, Time: 2000, SeqNumber: 2},
+		{Device: Device{ID: "d1"}, Time: 1000, SeqNumber: 1},
+	}
+	var sinces []string
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		q := r.URL.Query()
+		offset, _ := strconv.Atoi(q.Get("offset"))
+		if offset == 0 {
+			sinces = append(sinces, q.Get("since"))
+		}
+		since, _ := strconv.ParseInt(q.Get("since"), 10, 64)
+
+		var matching []Message
+		for _, m := range messages {
+			if m.Time.Millis() >= since {
+				matching = append(matching, m)
+			}
+		}
+
+		out := DeviceMessages{Data: []Message{}}
+		end := offset + 2
+		if end >= len(matching) {
+			end = len(matching)
+		} else {
+			q.Set("offset", strconv.Itoa(end))
+			out.Paging.Next = server.URL + r.URL.Path + "?" + q.Encode()
+		}
+		out.Data = append(out.Data, matching[offset:end]...)
+		json.NewEncoder(w).Encode(out)
+	}))
+	defer server.Close()
+
+	c, _ := NewClient("LOGIN_ID", "PASSWORD", WithBaseURL(server.URL))
+	store := NewFileCursorStore(filepath.Join(t.TempDir(), "cursors.json"))
+	opt := &PollOptions{Interval: 10 * time.Millisecond, Store: store}
+
+	receive := func(n int) []int32 {
+		ctx, cancel := context.WithCancel(context.Background())
+		out := make(chan Message)
+		done := make(chan error, 1)
+		go func() { done <- c.Device.PollMessages("d1", opt).Run(ctx, out) }()
+
+		var seqs []int32
+		for len(seqs) < n {
+			select {
+			case m := <-out:
+				seqs = append(seqs, m.SeqNumber)
+			case err := <-done:
+				t.Fatalf("Run returned early: %v", err)
+			case <-time.After(5 * time.Second):
+				t.Fatal("timed out waiting for messages")
+			}
+		}
+		// Let the poller poll again without anything new.
+		time.Sleep(50 * time.Millisecond)
+		cancel()
+		if err := <-done; err != context.Canceled {
+			t.Errorf("Run returned %v, want context.Canceled", err)
+		}
+		return seqs
+	}
+
+	if got, want := receive(3), []int32{1, 2, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("first run received %v, want %v", got, want)
+	}
+
+	mu.Lock()
+	messages = append([]Message{{Device: Device{ID: "d1"}, Time: 3000, SeqNumber: 4}}, messages...)
+	mu.Unlock()
+
+	// A restarted poller resumes from the saved cursor.
+	if got, want := receive(1), []int32{4}; !reflect.DeepEqual(got, want) {
+		t.Errorf("second run received %v, want %v", got, want)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if sinces[0] != "" || sinces[1] != "2000" {
+		t.Errorf("polls requested since %v", sinces)
+	}
+	if got := sinces[len(sinces)-1]; got != "3000" {
+		t.Errorf("last poll requested since %s, want 3000", got)
+	}
+}
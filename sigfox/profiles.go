@@ -2,7 +2,6 @@ package sigfox
 
 import (
 	"context"
-	"net/http"
 )
 
 type ProfileService service
@@ -38,30 +37,21 @@ type MinMetaRole struct {
 	Name string `json:"name,omitempty"`
 }
 
-// List retrieve a list of a Group's profiles according to visibility permissions and request filters.
-func (s *ProfileService) List(input *ListProfilesInput) (*ListProfilesOutput, *http.Response, error) {
-	return s.ListContext(context.Background(), input)
+// ListWithResponse retrieve a list of a Group's profiles according to visibility permissions and request filters.
+func (s *ProfileService) ListWithResponse(input *ListProfilesInput) (*ListProfilesOutput, *Response, error) {
+	return s.ListWithResponseContext(context.Background(), input)
 }
 
-// ListContext retrieve a list of a Group's profiles according to visibility permissions and request filters with context.
-func (s *ProfileService) ListContext(ctx context.Context, input *ListProfilesInput) (*ListProfilesOutput, *http.Response, error) {
+// ListWithResponseContext retrieve a list of a Group's profiles according to visibility permissions and request filters with context.
+func (s *ProfileService) ListWithResponseContext(ctx context.Context, input *ListProfilesInput) (*ListProfilesOutput, *Response, error) {
 	spath, err := addOptions("/profiles", input)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	req, err := s.client.newRequest(ctx, "GET", spath, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	res, err := s.client.Do(ctx, req)
-	if err != nil {
-		return nil, res, err
-	}
-
 	var out ListProfilesOutput
-	if err := decodeBody(res, &out); err != nil {
+	res, err := s.client.call(ctx, "GET", spath, nil, &out)
+	if err != nil {
 		return nil, res, err
 	}
 
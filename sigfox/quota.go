@@ -0,0 +1,183 @@
+package sigfox
+
+import (
+	"context"
+	"time"
+)
+
+// DefaultQuotaWindow is the number of complete days a QuotaTracker averages.
+const DefaultQuotaWindow = 7
+
+// DefaultQuotaWarningRatio is the share of a daily limit above which the average
+// consumption of a device is reported as trending to exceed it.
+const DefaultQuotaWarningRatio = 0.8
+
+// QuotaOptions configures a QuotaTracker.
+type QuotaOptions struct {
+	// Window is the number of complete days averaged. Defaults to DefaultQuotaWindow.
+	Window int
+	// WarningRatio is the share of the limits above which the average is flagged.
+	// Defaults to DefaultQuotaWarningRatio.
+	WarningRatio float64
+	// Location is the time zone in which the days are counted. Defaults to UTC.
+	Location *time.Location
+}
+
+// QuotaTracker compares the daily numbers of messages of devices with the daily
+// uplink and downlink limits of their contract.
+type QuotaTracker struct {
+	client       *Client
+	maxUplink    int32
+	maxDownlink  int32
+	window       int
+	warningRatio float64
+	location     *time.Location
+}
+
+// QuotaTracker returns a tracker checking devices against the limits of a contract.
+// The contract must be retrieved with ContractService.InfoWithResponse, as the contract of a
+// device type only holds its ID and name.
+func (s *DeviceService) QuotaTracker(contract *ContractInfo, opt *QuotaOptions) *QuotaTracker {
+	if opt == nil {
+		opt = &QuotaOptions{}
+	}
+	q := &QuotaTracker{
+		client:       s.client,
+		maxUplink:    contract.MaxUplinkFrames,
+		maxDownlink:  contract.MaxDownlinkFrames,
+		window:       opt.Window,
+		warningRatio: opt.WarningRatio,
+		location:     opt.Location,
+	}
+	if q.window <= 0 {
+		q.window = DefaultQuotaWindow
+	}
+	if q.warningRatio <= 0 {
+		q.warningRatio = DefaultQuotaWarningRatio
+	}
+	if q.location == nil {
+		q.location = time.UTC
+	}
+	return q
+}
+
+// QuotaUsage is the consumption of a device in one direction compared with its daily limit.
+type QuotaUsage struct {
+	// Max is the daily limit, zero meaning no limit.
+	Max int32
+	// Today is the number of messages of the current day so far.
+	Today int32
+	// Projected is the number of messages of the current day extrapolated to the end of the day.
+	Projected float64
+	// Average is the average number of messages of the complete days of the window.
+	Average float64
+	// Peak is the highest number of messages of a day of the window, the current day included.
+	Peak int32
+	// Exceeded reports that the limit was reached during the window.
+	Exceeded bool
+	// Trending reports that the limit was not reached yet, but that either the projection
+	// of the current day exceeds it or the average is above the warning ratio of it.
+	Trending bool
+}
+
+// QuotaReport is the consumption of a device compared with the limits of its contract.
+type QuotaReport struct {
+	Device   string
+	Day      time.Time
+	Uplink   QuotaUsage
+	Downlink QuotaUsage
+}
+
+// Flagged reports whether the device reached or is trending to exceed one of its limits.
+func (r *QuotaReport) Flagged() bool {
+	return r.Uplink.Exceeded || r.Uplink.Trending || r.Downlink.Exceeded || r.Downlink.Trending
+}
+
+// Check retrieve the consumption of a device and compares it with the limits.
+func (q *QuotaTracker) Check(deviceID string, now time.Time) (*QuotaReport, error) {
+	return q.CheckContext(context.Background(), deviceID, now)
+}
+
+// CheckContext retrieve the consumption of a device and compares it with the limits with context.
+// The consumption of the previous month is retrieved as well when the window starts before
+// the month of now.
+func (q *QuotaTracker) CheckContext(ctx context.Context, deviceID string, now time.Time) (*QuotaReport, error) {
+	now = now.In(q.location)
+	year, month, day := now.Date()
+
+	c, _, err := q.client.Device.MonthConsumptionWithResponseContext(ctx, deviceID, year, month)
+	if err != nil {
+		return nil, err
+	}
+	days := c.Consumption.Consumptions
+	if len(days) > day {
+		days = days[:day]
+	}
+
+	if day <= q.window {
+		prev := time.Date(year, month, 0, 0, 0, 0, 0, q.location)
+		c, _, err := q.client.Device.MonthConsumptionWithResponseContext(ctx, deviceID, prev.Year(), prev.Month())
+		if err != nil {
+			return nil, err
+		}
+		previous := c.Consumption.Consumptions
+		if len(previous) > prev.Day() {
+			previous = previous[:prev.Day()]
+		}
+		days = append(append([]DayConsumption(nil), previous...), days...)
+	}
+
+	return q.Evaluate(deviceID, days, now), nil
+}
+
+// Evaluate compares daily consumptions, the last one being the day of now, with the limits.
+func (q *QuotaTracker) Evaluate(deviceID string, days []DayConsumption, now time.Time) *QuotaReport {
+	now = now.In(q.location)
+	year, month, day := now.Date()
+	start := time.Date(year, month, day, 0, 0, 0, 0, q.location)
+
+	// The projection of the current day is not trusted before its first hour.
+	elapsed := now.Sub(start).Hours() / 24
+	if elapsed < 1.0/24 {
+		elapsed = 1.0 / 24
+	}
+
+	if len(days) > q.window+1 {
+		days = days[len(days)-q.window-1:]
+	}
+
+	report := &QuotaReport{Device: deviceID, Day: start}
+	report.Uplink = q.usage(days, elapsed, q.maxUplink, func(d *DayConsumption) int32 { return d.FrameCount })
+	report.Downlink = q.usage(days, elapsed, q.maxDownlink, func(d *DayConsumption) int32 { return d.DownlinkFrameCount })
+	return report
+}
+
+func (q *QuotaTracker) usage(days []DayConsumption, elapsed float64, max int32, count func(*DayConsumption) int32) QuotaUsage {
+	u := QuotaUsage{Max: max}
+	if len(days) == 0 {
+		return u
+	}
+
+	var sum int32
+	for i := range days {
+		n := count(&days[i])
+		if n > u.Peak {
+			u.Peak = n
+		}
+		if i < len(days)-1 {
+			sum += n
+		}
+	}
+	if complete := len(days) - 1; complete > 0 {
+		u.Average = float64(sum) / float64(complete)
+	}
+	u.Today = count(&days[len(days)-1])
+	u.Projected = float64(u.Today) / elapsed
+
+	if max > 0 {
+		u.Exceeded = u.Peak >= max
+		u.Trending = !u.Exceeded &&
+			(u.Projected > float64(max) || u.Average >= q.warningRatio*float64(max))
+	}
+	return u
+}
@@ -0,0 +1,102 @@
+package sigfox
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func days(counts ...int32) []DayConsumption {
+	d := make([]DayConsumption, len(counts))
+	for i, n := range counts {
+		d[i] = DayConsumption{FrameCount: n, DownlinkFrameCount: n / 35}
+	}
+	return d
+}
+
+func TestQuotaTracker_Evaluate(t *testing.T) {
+	c, _ := NewClient("LOGIN_ID", "PASSWORD")
+	q := c.Device.QuotaTracker(&ContractInfo{MaxUplinkFrames: 140, MaxDownlinkFrames: 4}, &QuotaOptions{Window: 3})
+	noon := time.Date(2020, 9, 13, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		days     []DayConsumption
+		now      time.Time
+		want     QuotaUsage
+		flagged  bool
+		downlink bool
+	}{
+		{"quiet", days(10, 20, 30, 10), noon,
+			QuotaUsage{Max: 140, Today: 10, Projected: 20, Average: 20, Peak: 30}, false, false},
+		{"window", days(140, 20, 30, 10, 10), noon,
+			QuotaUsage{Max: 140, Today: 10, Projected: 20, Average: 20, Peak: 30}, false, false},
+		{"exceeded", days(20, 140, 30, 10), noon,
+			QuotaUsage{Max: 140, Today: 10, Projected: 20, Average: 190.0 / 3, Peak: 140, Exceeded: true}, true, true},
+		{"average", days(120, 110, 130, 10), noon,
+			QuotaUsage{Max: 140, Today: 10, Projected: 20, Average: 120, Peak: 130, Trending: true}, true, false},
+		{"projected", days(10, 20, 30, 75), noon,
+			QuotaUsage{Max: 140, Today: 75, Projected: 150, Average: 20, Peak: 75, Trending: true}, true, false},
+		{"first hour", days(10, 20, 30, 5), noon.Add(-12 * time.Hour),
+			QuotaUsage{Max: 140, Today: 5, Projected: 120, Average: 20, Peak: 30}, false, false},
+		{"first day", days(70), noon,
+			QuotaUsage{Max: 140, Today: 70, Projected: 140, Peak: 70}, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := q.Evaluate("d1", tt.days, tt.now)
+			if r.Uplink != tt.want {
+				t.Errorf("Uplink = %+v, want %+v", r.Uplink, tt.want)
+			}
+			if got := r.Flagged(); got != tt.flagged {
+				t.Errorf("Flagged() = %v, want %v", got, tt.flagged)
+			}
+			if got := r.Downlink.Exceeded || r.Downlink.Trending; got != tt.downlink {
+				t.Errorf("Downlink = %+v, want flagged %v", r.Downlink, tt.downlink)
+			}
+		})
+	}
+}
+
+func TestQuotaTracker_noLimit(t *testing.T) {
+	c, _ := NewClient("LOGIN_ID", "PASSWORD")
+	q := c.Device.QuotaTracker(&ContractInfo{}, nil)
+
+	r := q.Evaluate("d1", days(1000, 2000), time.Date(2020, 9, 13, 12, 0, 0, 0, time.UTC))
+	if r.Flagged() {
+		t.Errorf("Flagged() = true without limits: %+v", r)
+	}
+}
+
+func TestQuotaTracker_Check(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/devices/d1/consumptions/2020/9", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"consumption":{"id":"d1","consumptions":[{"frameCount":130},{"frameCount":100},{"frameCount":0}]}}`)
+	})
+	mux.HandleFunc("/devices/d1/consumptions/2020/8", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"consumption":{"id":"d1","consumptions":[`+
+			`{},{},{},{},{},{},{},{},{},{},{},{},{},{},{},{},{},{},{},{},{},{},{},{},{},{},{},{},{},{},`+
+			`{"frameCount":120,"downlinkFrameCount":1,"roamingDetails":[{"territoryOperator":"OP","frameCount":3}]},{}]}}`)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	c, _ := NewClient("LOGIN_ID", "PASSWORD", WithBaseURL(server.URL))
+	q := c.Device.QuotaTracker(&ContractInfo{MaxUplinkFrames: 140, MaxDownlinkFrames: 4}, &QuotaOptions{Window: 3})
+
+	r, err := q.CheckContext(context.Background(), "d1", time.Date(2020, 9, 2, 6, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("CheckContext returned error: %v", err)
+	}
+
+	want := QuotaUsage{Max: 140, Today: 100, Projected: 400, Average: 250.0 / 3, Peak: 130, Trending: true}
+	if r.Uplink != want {
+		t.Errorf("Uplink = %+v, want %+v", r.Uplink, want)
+	}
+	if want := time.Date(2020, 9, 2, 0, 0, 0, 0, time.UTC); !r.Day.Equal(want) {
+		t.Errorf("Day = %v, want %v", r.Day, want)
+	}
+}
@@ -0,0 +1,130 @@
+package sigfox
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// flexFloat decodes a number given either as a JSON number or as a string,
+// as the API does for the radio quality fields. Empty strings decode to 0.
+type flexFloat float64
+
+func (f *flexFloat) UnmarshalJSON(data []byte) error {
+	var n float64
+	if err := json.Unmarshal(data, &n); err == nil {
+		*f = flexFloat(n)
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("invalid number %s", data)
+	}
+	s = strings.TrimSpace(s)
+	if s == "" {
+		*f = 0
+		return nil
+	}
+	n, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return fmt.Errorf("invalid number %q", s)
+	}
+	*f = flexFloat(n)
+	return nil
+}
+
+// UnmarshalJSON decodes the radio quality fields from either numbers or strings.
+func (r *Rinfo) UnmarshalJSON(data []byte) error {
+	type rinfo Rinfo
+	aux := struct {
+		*rinfo
+		Rssi          flexFloat `json:"rssi"`
+		RssiRepeaters flexFloat `json:"rssiRepeaters"`
+		Lat           flexFloat `json:"lat"`
+		Lng           flexFloat `json:"lng"`
+		Snr           flexFloat `json:"snr"`
+		SnrRepeaters  flexFloat `json:"snrRepeaters"`
+		Freq          flexFloat `json:"freq"`
+		FreqRepeaters flexFloat `json:"freqRepeaters"`
+	}{rinfo: (*rinfo)(r)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	r.Rssi = float64(aux.Rssi)
+	r.RssiRepeaters = float64(aux.RssiRepeaters)
+	r.Lat = float64(aux.Lat)
+	r.Lng = float64(aux.Lng)
+	r.Snr = float64(aux.Snr)
+	r.SnrRepeaters = float64(aux.SnrRepeaters)
+	r.Freq = float64(aux.Freq)
+	r.FreqRepeaters = float64(aux.FreqRepeaters)
+	return nil
+}
+
+// UnmarshalJSON decodes the average SNR and RSSI from either numbers or strings.
+func (d *Device) UnmarshalJSON(data []byte) error {
+	type device Device
+	aux := struct {
+		*device
+		AverageSnr  flexFloat `json:"averageSnr"`
+		AverageRssi flexFloat `json:"averageRssi"`
+	}{device: (*device)(d)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	d.AverageSnr = float64(aux.AverageSnr)
+	d.AverageRssi = float64(aux.AverageRssi)
+	return nil
+}
+
+// BestRssi returns the highest RSSI, in dBm, among the base stations which received
+// the message, and false if the message holds no radio information.
+func (m *Message) BestRssi() (float64, bool) {
+	return m.bestRinfo(func(r *Rinfo) float64 { return r.Rssi })
+}
+
+// BestSnr returns the highest SNR, in dB, among the base stations which received
+// the message, and false if the message holds no radio information.
+func (m *Message) BestSnr() (float64, bool) {
+	return m.bestRinfo(func(r *Rinfo) float64 { return r.Snr })
+}
+
+// AverageRssi returns the average RSSI, in dBm, of the base stations which received
+// the message, and false if the message holds no radio information.
+func (m *Message) AverageRssi() (float64, bool) {
+	return m.averageRinfo(func(r *Rinfo) float64 { return r.Rssi })
+}
+
+// AverageSnr returns the average SNR, in dB, of the base stations which received
+// the message, and false if the message holds no radio information.
+func (m *Message) AverageSnr() (float64, bool) {
+	return m.averageRinfo(func(r *Rinfo) float64 { return r.Snr })
+}
+
+func (m *Message) bestRinfo(value func(*Rinfo) float64) (float64, bool) {
+	if len(m.Rinfos) == 0 {
+		return 0, false
+	}
+	best := value(&m.Rinfos[0])
+	for i := range m.Rinfos[1:] {
+		if v := value(&m.Rinfos[i+1]); v > best {
+			best = v
+		}
+	}
+	return best, true
+}
+
+func (m *Message) averageRinfo(value func(*Rinfo) float64) (float64, bool) {
+	if len(m.Rinfos) == 0 {
+		return 0, false
+	}
+	var sum float64
+	for i := range m.Rinfos {
+		sum += value(&m.Rinfos[i])
+	}
+	return sum / float64(len(m.Rinfos)), true
+}
@@ -0,0 +1,53 @@
+package sigfox
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestMessage_radio(t *testing.T) {
+	data := `{"device":{"id":"d1","averageSnr":"21.50","averageRssi":-118.5},"time":1600000000000,"rinfos":[
+		{"baseStation":{"id":"0A1B"},"rssi":"-120.00","snr":"10.50","lat":"43.0","lng":"1.5","freqRepeaters":868.1},
+		{"baseStation":{"id":"0A1C"},"rssi":-110,"snr":20.5,"lat":"","rssiRepeaters":"-100"}
+	]}`
+
+	var m Message
+	if err := json.Unmarshal([]byte(data), &m); err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+
+	if m.Device.AverageSnr != 21.5 || m.Device.AverageRssi != -118.5 || m.Device.ID != "d1" {
+		t.Errorf("Device decoded as %+v", m.Device)
+	}
+	r := m.Rinfos[0]
+	if r.Rssi != -120 || r.Snr != 10.5 || r.Lat != 43 || r.Lng != 1.5 || r.FreqRepeaters != 868.1 || r.BaseStation.ID != "0A1B" {
+		t.Errorf("Rinfo decoded as %+v", r)
+	}
+	if m.Rinfos[1].RssiRepeaters != -100 {
+		t.Errorf("RssiRepeaters is %v, want -100", m.Rinfos[1].RssiRepeaters)
+	}
+
+	tests := []struct {
+		name string
+		fn   func() (float64, bool)
+		want float64
+	}{
+		{"BestRssi", m.BestRssi, -110},
+		{"BestSnr", m.BestSnr, 20.5},
+		{"AverageRssi", m.AverageRssi, -115},
+		{"AverageSnr", m.AverageSnr, 15.5},
+	}
+	for _, tt := range tests {
+		if got, ok := tt.fn(); !ok || got != tt.want {
+			t.Errorf("%s() = %v, %v, want %v", tt.name, got, ok, tt.want)
+		}
+	}
+
+	if _, ok := (&Message{}).BestRssi(); ok {
+		t.Error("BestRssi() reported a value for a message without radio information")
+	}
+
+	if err := json.Unmarshal([]byte(`{"rssi":"strong"}`), &Rinfo{}); err == nil {
+		t.Error("Unmarshal accepted an invalid RSSI")
+	}
+}
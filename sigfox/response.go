@@ -0,0 +1,102 @@
+package sigfox
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// Response wraps the HTTP response returned by the Sigfox API.
+type Response struct {
+	*http.Response
+
+	// NextPage is the paging.next URL of a paginated listing.
+	// It is empty on the last page and for the other endpoints.
+	NextPage string
+
+	// Rate is the rate limit status reported by the response headers.
+	Rate Rate
+}
+
+// Rate represents the rate limit status of the account.
+// Its fields are left to their zero value when the headers are missing.
+type Rate struct {
+	Limit     int
+	Remaining int
+	Reset     time.Time
+}
+
+const (
+	headerRateLimit     = "X-RateLimit-Limit"
+	headerRateRemaining = "X-RateLimit-Remaining"
+	headerRateReset     = "X-RateLimit-Reset"
+)
+
+func newResponse(r *http.Response) *Response {
+	response := &Response{Response: r}
+
+	if v, err := strconv.Atoi(r.Header.Get(headerRateLimit)); err == nil {
+		response.Rate.Limit = v
+	}
+	if v, err := strconv.Atoi(r.Header.Get(headerRateRemaining)); err == nil {
+		response.Rate.Remaining = v
+	}
+	if v, err := strconv.ParseInt(r.Header.Get(headerRateReset), 10, 64); err == nil {
+		response.Rate.Reset = time.Unix(v, 0)
+	}
+
+	return response
+}
+
+// nextPage returns the paging.next URL of a decoded listing, if any.
+func nextPage(out interface{}) string {
+	v := reflect.ValueOf(out)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return ""
+	}
+
+	f := v.Elem().FieldByName("Paging")
+	if !f.IsValid() {
+		return ""
+	}
+	if p, ok := f.Interface().(Pagination); ok {
+		return p.Next
+	}
+	return ""
+}
+
+// call builds a request, sends it through Do and decodes the response body into out.
+// The response body is always consumed, so out may be nil when no output is expected.
+func (c *Client) call(ctx context.Context, method, spath string, body, out interface{}) (*Response, error) {
+	req, err := c.newRequest(ctx, method, spath, body)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.Do(ctx, req)
+	if res == nil {
+		return nil, err
+	}
+
+	response := newResponse(res)
+	if err != nil {
+		return response, err
+	}
+
+	if out == nil {
+		// Draining the body lets the connection be reused.
+		io.Copy(ioutil.Discard, res.Body)
+		res.Body.Close()
+		return response, nil
+	}
+	if err := decodeBody(res, out); err != nil {
+		return response, err
+	}
+	response.NextPage = nextPage(out)
+
+	return response, nil
+}
@@ -0,0 +1,80 @@
+package sigfox
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "100")
+		w.Header().Set("X-RateLimit-Remaining", "42")
+		w.Header().Set("X-RateLimit-Reset", "1600000000")
+		w.Write([]byte(`{"data":[{"id":"1"}],"paging":{"next":"https://api.sigfox.com/v2/devices?offset=1"}}`))
+	}))
+	defer server.Close()
+
+	c, _ := NewClient("LOGIN_ID", "PASSWORD", WithBaseURL(server.URL))
+	_, res, err := c.Device.ListWithResponseContext(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("ListContext returned error: %v", err)
+	}
+
+	if got, want := res.StatusCode, http.StatusOK; got != want {
+		t.Errorf("StatusCode is %d, want %d", got, want)
+	}
+	if got, want := res.NextPage, "https://api.sigfox.com/v2/devices?offset=1"; got != want {
+		t.Errorf("NextPage is %q, want %q", got, want)
+	}
+	if res.Rate.Limit != 100 || res.Rate.Remaining != 42 || res.Rate.Reset.Unix() != 1600000000 {
+		t.Errorf("Rate is %+v", res.Rate)
+	}
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+// trackedBody records whether a response body was read to its end.
+type trackedBody struct {
+	io.ReadCloser
+	eof bool
+}
+
+func (b *trackedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if err == io.EOF {
+		b.eof = true
+	}
+	return n, err
+}
+
+func TestCall_drainsBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"unexpected":"output"}`))
+	}))
+	defer server.Close()
+
+	var body *trackedBody
+	c, _ := NewClient("LOGIN_ID", "PASSWORD", WithBaseURL(server.URL),
+		WithTransport(func(rt http.RoundTripper) http.RoundTripper {
+			return roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				res, err := rt.RoundTrip(req)
+				if err == nil {
+					body = &trackedBody{ReadCloser: res.Body}
+					res.Body = body
+				}
+				return res, err
+			})
+		}),
+	)
+	if _, err := c.Device.DeleteWithResponseContext(context.Background(), "d1"); err != nil {
+		t.Fatalf("DeleteWithResponseContext returned error: %v", err)
+	}
+	if body == nil || !body.eof {
+		t.Error("call closed the response body without reading it")
+	}
+}
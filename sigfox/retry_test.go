@@ -0,0 +1,104 @@
+package sigfox
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func setupRetryServer(t *testing.T, handler http.HandlerFunc) (*Client, func()) {
+	server := httptest.NewServer(handler)
+
+	c, _ := NewClient("LOGIN_ID", "PASSWORD", WithBaseURL(server.URL))
+	c.RetryPolicy = &RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond, MaxBackoff: 10 * time.Millisecond}
+
+	return c, server.Close
+}
+
+func TestDo_retry(t *testing.T) {
+	var calls int
+	c, teardown := setupRetryServer(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{}`))
+	})
+	defer teardown()
+
+	ctx := context.Background()
+	req, _ := c.newRequest(ctx, "GET", "/foo", nil)
+	if _, err := c.Do(ctx, req); err != nil {
+		t.Fatalf("Do returned error: %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("Do sent %d requests, want 3", calls)
+	}
+}
+
+func TestDo_noRetryPost(t *testing.T) {
+	var calls int
+	c, teardown := setupRetryServer(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	defer teardown()
+
+	ctx := context.Background()
+	req, _ := c.newRequest(ctx, "POST", "/foo", struct{}{})
+	if _, err := c.Do(ctx, req); err == nil {
+		t.Error("Do returned no error")
+	}
+	if calls != 1 {
+		t.Errorf("Do sent %d requests, want 1", calls)
+	}
+
+	calls = 0
+	c.RetryPolicy.RetryPost = true
+	req, _ = c.newRequest(ctx, "POST", "/foo", struct{}{})
+	c.Do(ctx, req)
+	if calls != 3 {
+		t.Errorf("Do sent %d requests with RetryPost, want 3", calls)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	if d, ok := retryAfter("5"); !ok || d != 5*time.Second {
+		t.Errorf("retryAfter(%q) = %v, %v, want %v, true", "5", d, ok, 5*time.Second)
+	}
+	if _, ok := retryAfter("soon"); ok {
+		t.Errorf("retryAfter(%q) succeeded, want failure", "soon")
+	}
+}
+
+func TestBackoff_retryAfterCapped(t *testing.T) {
+	p := &RetryPolicy{MinBackoff: time.Second, MaxBackoff: 10 * time.Second}
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"3600"}}}
+	if d := p.backoff(1, resp); d != 10*time.Second {
+		t.Errorf("backoff = %v, want %v", d, 10*time.Second)
+	}
+
+	resp.Header.Set("Retry-After", "5")
+	if d := p.backoff(1, resp); d != 5*time.Second {
+		t.Errorf("backoff = %v, want %v", d, 5*time.Second)
+	}
+}
+
+func TestRateLimiter(t *testing.T) {
+	l := NewRateLimiter(100, 1)
+	ctx := context.Background()
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := l.Wait(ctx); err != nil {
+			t.Fatalf("Wait returned error: %v", err)
+		}
+	}
+	if d := time.Since(start); d < 15*time.Millisecond {
+		t.Errorf("3 requests at 100/s with burst 1 took %v, want at least 20ms", d)
+	}
+}
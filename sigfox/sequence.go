@@ -0,0 +1,221 @@
+package sigfox
+
+import (
+	"sort"
+	"sync"
+)
+
+// SequenceNumberModulo is the number of sequence numbers of a device:
+// they are 12-bit counters rolling over from 4095 to 0.
+const SequenceNumberModulo = 4096
+
+// DefaultResetThreshold is the sequence number below which a frame going
+// backwards is taken as the first frames of a device which was reset.
+const DefaultResetThreshold = 16
+
+// SequenceDistance returns how many frames separate two sequence numbers,
+// from 0 to SequenceNumberModulo-1, taking the rollover into account.
+func SequenceDistance(from, to int32) int32 {
+	return ((to-from)%SequenceNumberModulo + SequenceNumberModulo) % SequenceNumberModulo
+}
+
+// SequenceAnomaly is the kind of a SequenceEvent.
+type SequenceAnomaly string
+
+const (
+	// SequenceGap reports frames missing between two received frames.
+	SequenceGap SequenceAnomaly = "gap"
+	// SequenceDuplicate reports a frame received twice, with the same time.
+	SequenceDuplicate SequenceAnomaly = "duplicate"
+	// SequenceReplay reports a frame reusing a sequence number already received,
+	// or going backwards without a device reset.
+	SequenceReplay SequenceAnomaly = "replay"
+	// SequenceReset reports a device whose sequence number restarted from 0.
+	// Its frames are dropped by the backend until DisengageSequenceNumber is called.
+	SequenceReset SequenceAnomaly = "reset"
+)
+
+// SequenceEvent is an anomaly found in the sequence numbers of a device.
+type SequenceEvent struct {
+	Anomaly   SequenceAnomaly
+	Device    string
+	SeqNumber int32
+	// Previous is the sequence number of the last frame in order before this one.
+	Previous int32
+	// Missing is the number of frames missing for SequenceGap events.
+	Missing int32
+	Time    Timestamp
+}
+
+// SequenceStats sums up the frames analyzed for a device.
+type SequenceStats struct {
+	Received   int
+	Missing    int
+	Duplicates int
+	Replays    int
+	Resets     int
+	// Last is the sequence number of the last frame in order.
+	Last int32
+}
+
+// SequenceAnalyzer tracks the sequence numbers of devices across batches of messages.
+// It is safe for concurrent use.
+type SequenceAnalyzer struct {
+	// ResetThreshold is the sequence number below which a frame going backwards
+	// is taken as a device reset. Defaults to DefaultResetThreshold.
+	ResetThreshold int32
+
+	mu      sync.Mutex
+	devices map[string]*deviceSequence
+}
+
+type deviceSequence struct {
+	stats SequenceStats
+	// lastTime is the time of the last frame in order.
+	lastTime Timestamp
+	// times holds the times of the frames received within the last half of the
+	// sequence number space, and missing the frames reported missing in it.
+	times   map[int32]Timestamp
+	missing map[int32]bool
+}
+
+// NewSequenceAnalyzer returns an analyzer which has not seen any frame.
+func NewSequenceAnalyzer() *SequenceAnalyzer {
+	return &SequenceAnalyzer{devices: make(map[string]*deviceSequence)}
+}
+
+// Add analyzes a batch of messages, such as a page returned by the API, and returns
+// the anomalies found. Messages are analyzed by device in chronological order.
+// A frame older than the last one in order filling a gap reported by a previous batch
+// reduces the missing count.
+func (a *SequenceAnalyzer) Add(messages ...Message) []SequenceEvent {
+	sorted := make([]Message, len(messages))
+	copy(sorted, messages)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Time < sorted[j].Time
+	})
+
+	threshold := a.ResetThreshold
+	if threshold <= 0 {
+		threshold = DefaultResetThreshold
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	var events []SequenceEvent
+	for i := range sorted {
+		m := &sorted[i]
+		d, ok := a.devices[m.Device.ID]
+		if !ok {
+			d = &deviceSequence{times: make(map[int32]Timestamp), missing: make(map[int32]bool)}
+			a.devices[m.Device.ID] = d
+			d.stats.Received++
+			d.stats.Last = m.SeqNumber
+			d.lastTime = m.Time
+			d.times[m.SeqNumber] = m.Time
+			continue
+		}
+
+		if e, ok := d.add(m, threshold); ok {
+			e.Device = m.Device.ID
+			events = append(events, e)
+		}
+	}
+	return events
+}
+
+func (d *deviceSequence) add(m *Message, threshold int32) (SequenceEvent, bool) {
+	seq := m.SeqNumber
+	e := SequenceEvent{SeqNumber: seq, Previous: d.stats.Last, Time: m.Time}
+
+	if t, ok := d.times[seq]; ok {
+		if t == m.Time {
+			d.stats.Duplicates++
+			e.Anomaly = SequenceDuplicate
+		} else {
+			d.stats.Replays++
+			e.Anomaly = SequenceReplay
+		}
+		return e, true
+	}
+
+	d.stats.Received++
+	dist := SequenceDistance(d.stats.Last, seq)
+	switch {
+	case dist < SequenceNumberModulo/2:
+		d.advance(seq)
+		d.lastTime = m.Time
+		d.times[seq] = m.Time
+		if dist > 1 {
+			for k := int32(1); k < dist; k++ {
+				d.missing[(e.Previous+k)%SequenceNumberModulo] = true
+			}
+			d.stats.Missing += int(dist - 1)
+			e.Anomaly = SequenceGap
+			e.Missing = dist - 1
+			return e, true
+		}
+		return e, false
+	case d.missing[seq] && m.Time <= d.lastTime:
+		delete(d.missing, seq)
+		d.stats.Missing--
+		d.times[seq] = m.Time
+		return e, false
+	case seq < threshold:
+		d.stats.Resets++
+		d.stats.Last = seq
+		d.lastTime = m.Time
+		d.times = map[int32]Timestamp{seq: m.Time}
+		d.missing = make(map[int32]bool)
+		e.Anomaly = SequenceReset
+		return e, true
+	default:
+		d.stats.Replays++
+		e.Anomaly = SequenceReplay
+		return e, true
+	}
+}
+
+// advance moves the last sequence number forward, forgetting the frames
+// which are now more than half the sequence number space behind.
+func (d *deviceSequence) advance(seq int32) {
+	for k := d.stats.Last + 1; SequenceDistance(k, seq) != 0; k++ {
+		stale := (k + SequenceNumberModulo/2) % SequenceNumberModulo
+		delete(d.times, stale)
+		delete(d.missing, stale)
+	}
+	stale := (seq + SequenceNumberModulo/2) % SequenceNumberModulo
+	delete(d.times, stale)
+	delete(d.missing, stale)
+	d.stats.Last = seq
+}
+
+// Stats returns the statistics of a device, and false if no frame of the device was analyzed.
+func (a *SequenceAnalyzer) Stats(deviceID string) (SequenceStats, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	d, ok := a.devices[deviceID]
+	if !ok {
+		return SequenceStats{}, false
+	}
+	return d.stats, true
+}
+
+// NeedsDisengage reports whether the sequence number of a device should be disengaged
+// with DisengageSequenceNumber: the backend trashed a frame whose sequence number is
+// behind the last accepted one and below the reset threshold, which happens when a device
+// restarts its sequence from 0 after a reset. As the API leaves TrashSequenceNumber
+// unset until a frame is trashed, a trashed frame 0 alone is not detected.
+func (a *SequenceAnalyzer) NeedsDisengage(d *Device) bool {
+	threshold := a.ResetThreshold
+	if threshold <= 0 {
+		threshold = DefaultResetThreshold
+	}
+
+	if d.TrashSequenceNumber <= 0 || d.TrashSequenceNumber >= threshold {
+		return false
+	}
+	return SequenceDistance(d.SequenceNumber, d.TrashSequenceNumber) >= SequenceNumberModulo/2
+}
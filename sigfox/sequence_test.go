@@ -0,0 +1,84 @@
+package sigfox
+
+import (
+	"reflect"
+	"testing"
+)
+
+func seqMessage(seq int32, time Timestamp) Message {
+	return Message{Device: Device{ID: "d1"}, SeqNumber: seq, Time: time}
+}
+
+func TestSequenceDistance(t *testing.T) {
+	tests := []struct{ from, to, want int32 }{
+		{1, 2, 1},
+		{4095, 0, 1},
+		{4090, 3, 9},
+		{5, 5, 0},
+		{5, 4, 4095},
+	}
+	for _, tt := range tests {
+		if got := SequenceDistance(tt.from, tt.to); got != tt.want {
+			t.Errorf("SequenceDistance(%d, %d) = %d, want %d", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+func TestSequenceAnalyzer(t *testing.T) {
+	a := NewSequenceAnalyzer()
+
+	// A batch listed most recent first, rolling over and missing 4095 and 1.
+	events := a.Add(seqMessage(2, 5000), seqMessage(0, 4000), seqMessage(4094, 3000), seqMessage(4093, 2000))
+	want := []SequenceEvent{
+		{Anomaly: SequenceGap, Device: "d1", SeqNumber: 0, Previous: 4094, Missing: 1, Time: 4000},
+		{Anomaly: SequenceGap, Device: "d1", SeqNumber: 2, Previous: 0, Missing: 1, Time: 5000},
+	}
+	if !reflect.DeepEqual(events, want) {
+		t.Errorf("Add returned %+v, want %+v", events, want)
+	}
+
+	// The late frame 4095 fills a gap, 2 is a duplicate and 0 is replayed.
+	events = a.Add(seqMessage(4095, 3500), seqMessage(2, 5000), seqMessage(0, 6000), seqMessage(3, 7000))
+	want = []SequenceEvent{
+		{Anomaly: SequenceDuplicate, Device: "d1", SeqNumber: 2, Previous: 2, Time: 5000},
+		{Anomaly: SequenceReplay, Device: "d1", SeqNumber: 0, Previous: 2, Time: 6000},
+	}
+	if !reflect.DeepEqual(events, want) {
+		t.Errorf("Add returned %+v, want %+v", events, want)
+	}
+
+	// The device is reset.
+	events = a.Add(seqMessage(1, 8000), seqMessage(2, 9000))
+	want = []SequenceEvent{
+		{Anomaly: SequenceReset, Device: "d1", SeqNumber: 1, Previous: 3, Time: 8000},
+	}
+	if !reflect.DeepEqual(events, want) {
+		t.Errorf("Add returned %+v, want %+v", events, want)
+	}
+
+	stats, ok := a.Stats("d1")
+	wantStats := SequenceStats{Received: 8, Missing: 1, Duplicates: 1, Replays: 1, Resets: 1, Last: 2}
+	if !ok || stats != wantStats {
+		t.Errorf("Stats returned %+v, want %+v", stats, wantStats)
+	}
+}
+
+func TestSequenceAnalyzer_NeedsDisengage(t *testing.T) {
+	a := NewSequenceAnalyzer()
+	tests := []struct {
+		seq, trash int32
+		want       bool
+	}{
+		{1200, 0, false},
+		{1200, 2, true},
+		{1200, 1100, false},
+		{2, 5, false},
+		{4000, 5, false},
+	}
+	for _, tt := range tests {
+		d := &Device{SequenceNumber: tt.seq, TrashSequenceNumber: tt.trash}
+		if got := a.NeedsDisengage(d); got != tt.want {
+			t.Errorf("NeedsDisengage(%d, %d) = %v, want %v", tt.seq, tt.trash, got, tt.want)
+		}
+	}
+}
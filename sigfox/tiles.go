@@ -2,7 +2,6 @@ package sigfox
 
 import (
 	"context"
-	"net/http"
 )
 
 type TileService service
@@ -23,25 +22,16 @@ type LatLng struct {
 	Lng float64 `json:"lng,omitempty"`
 }
 
-// Monarch retrieve the information needed to display Sigfox Monarch service coverage.
-func (s *TileService) Monarch() (*TileMonarchOutput, *http.Response, error) {
-	return s.MonarchContext(context.Background())
+// MonarchWithResponse retrieve the information needed to display Sigfox Monarch service coverage.
+func (s *TileService) MonarchWithResponse() (*TileMonarchOutput, *Response, error) {
+	return s.MonarchWithResponseContext(context.Background())
 }
 
-// MonarchContext retrieve the information needed to display Sigfox Monarch service coverage with context.
-func (s *TileService) MonarchContext(ctx context.Context) (*TileMonarchOutput, *http.Response, error) {
-	req, err := s.client.newRequest(ctx, "GET", "/tiles/monarch", nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	res, err := s.client.Do(ctx, req)
-	if err != nil {
-		return nil, res, err
-	}
-
+// MonarchWithResponseContext retrieve the information needed to display Sigfox Monarch service coverage with context.
+func (s *TileService) MonarchWithResponseContext(ctx context.Context) (*TileMonarchOutput, *Response, error) {
 	var out TileMonarchOutput
-	if err := decodeBody(res, &out); err != nil {
+	res, err := s.client.call(ctx, "GET", "/tiles/monarch", nil, &out)
+	if err != nil {
 		return nil, res, err
 	}
 
@@ -0,0 +1,86 @@
+package sigfox
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Timestamp is a time given by the API in milliseconds since the Unix Epoch.
+// It is encoded as the integer the API expects, and decoded from an integer,
+// a string holding an integer or an RFC 3339 string.
+type Timestamp int64
+
+// NewTimestamp returns the timestamp of t, truncated to the millisecond.
+// The zero time gives the zero timestamp.
+func NewTimestamp(t time.Time) Timestamp {
+	if t.IsZero() {
+		return 0
+	}
+	return Timestamp(t.UnixNano() / int64(time.Millisecond))
+}
+
+// Time returns the timestamp as a time.Time, or the zero time for the zero timestamp.
+func (t Timestamp) Time() time.Time {
+	if t == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, int64(t)*int64(time.Millisecond))
+}
+
+// IsZero reports whether the timestamp is unset.
+func (t Timestamp) IsZero() bool {
+	return t == 0
+}
+
+// Millis returns the number of milliseconds since the Unix Epoch.
+func (t Timestamp) Millis() int64 {
+	return int64(t)
+}
+
+// String returns the time in RFC 3339 format with milliseconds, in UTC.
+func (t Timestamp) String() string {
+	if t == 0 {
+		return "0"
+	}
+	return t.Time().UTC().Format("2006-01-02T15:04:05.000Z07:00")
+}
+
+// EncodeValues adds the milliseconds of the timestamp to the query string under key.
+func (t Timestamp) EncodeValues(key string, v *url.Values) error {
+	v.Add(key, strconv.FormatInt(int64(t), 10))
+	return nil
+}
+
+func (t *Timestamp) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+
+	var ms int64
+	if err := json.Unmarshal(data, &ms); err == nil {
+		*t = Timestamp(ms)
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("invalid timestamp %s", data)
+	}
+	if s == "" {
+		*t = 0
+		return nil
+	}
+	if ms, err := strconv.ParseInt(s, 10, 64); err == nil {
+		*t = Timestamp(ms)
+		return nil
+	}
+	tm, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return fmt.Errorf("invalid timestamp %q", s)
+	}
+	*t = NewTimestamp(tm)
+	return nil
+}
@@ -0,0 +1,57 @@
+package sigfox
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestTimestamp(t *testing.T) {
+	tm := time.Date(2020, 9, 13, 12, 26, 40, 123456789, time.UTC)
+	ts := NewTimestamp(tm)
+	if ts != 1600000000123 {
+		t.Errorf("NewTimestamp returned %d, want 1600000000123", ts)
+	}
+	if got := ts.Time(); !got.Equal(tm.Truncate(time.Millisecond)) {
+		t.Errorf("Time() is %v, want %v", got, tm)
+	}
+	if got, want := ts.String(), "2020-09-13T12:26:40.123Z"; got != want {
+		t.Errorf("String() is %q, want %q", got, want)
+	}
+	if !Timestamp(0).Time().IsZero() || NewTimestamp(time.Time{}) != 0 {
+		t.Error("the zero timestamp does not match the zero time")
+	}
+}
+
+func TestTimestamp_JSON(t *testing.T) {
+	for _, data := range []string{`1600000000123`, `"1600000000123"`, `"2020-09-13T12:26:40.123Z"`, `"2020-09-13T14:26:40.123+02:00"`} {
+		var ts Timestamp
+		if err := json.Unmarshal([]byte(data), &ts); err != nil || ts != 1600000000123 {
+			t.Errorf("Unmarshal(%s) decoded %d, %v", data, ts, err)
+		}
+	}
+
+	var ts Timestamp
+	if err := json.Unmarshal([]byte(`"yesterday"`), &ts); err == nil {
+		t.Error("Unmarshal accepted an invalid timestamp")
+	}
+
+	b, _ := json.Marshal(Message{Time: 1600000000123})
+	if want := `{"device":{},"time":1600000000123}`; string(b) != want {
+		t.Errorf("Marshal returned %s, want %s", b, want)
+	}
+}
+
+func TestTimestamp_query(t *testing.T) {
+	opt := &QueryParams{}
+	SinceTime(time.Unix(1600000000, 0))(opt)
+	BeforeTime(time.Time{})(opt)
+
+	spath, err := addOptions("/devices/d1/messages", opt)
+	if err != nil {
+		t.Fatalf("addOptions returned error: %v", err)
+	}
+	if want := "/devices/d1/messages?since=1600000000000"; spath != want {
+		t.Errorf("addOptions returned %q, want %q", spath, want)
+	}
+}
@@ -0,0 +1,136 @@
+package sigfox
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Trajectory is the path of a device: its valid locations in chronological order.
+type Trajectory []DeviceLocation
+
+// NewTrajectory returns the trajectory going through the valid locations, such as those
+// returned by Locations. Locations of the same time keep the order of the API reversed.
+func NewTrajectory(locations []DeviceLocation) Trajectory {
+	t := make(Trajectory, 0, len(locations))
+	for _, l := range locations {
+		if l.Valid {
+			t = append(t, l)
+		}
+	}
+
+	// The API lists the most recent locations first.
+	for i, j := 0, len(t)-1; i < j; i, j = i+1, j-1 {
+		t[i], t[j] = t[j], t[i]
+	}
+	sort.SliceStable(t, func(i, j int) bool {
+		return t[i].Time < t[j].Time
+	})
+	return t
+}
+
+type geoJSONCollection struct {
+	Type     string           `json:"type"`
+	Features []geoJSONFeature `json:"features"`
+}
+
+type geoJSONFeature struct {
+	Type       string                 `json:"type"`
+	Geometry   geoJSONGeometry        `json:"geometry"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+type geoJSONGeometry struct {
+	Type        string      `json:"type"`
+	Coordinates interface{} `json:"coordinates"`
+}
+
+// GeoJSON encodes the trajectory as a GeoJSON FeatureCollection: a LineString feature
+// going through the locations, when there are at least two, followed by a Point feature
+// per location with its time, radius and source as properties.
+func (t Trajectory) GeoJSON() ([]byte, error) {
+	c := geoJSONCollection{Type: "FeatureCollection", Features: []geoJSONFeature{}}
+
+	if len(t) >= 2 {
+		line := make([][2]float64, len(t))
+		for i, l := range t {
+			line[i] = [2]float64{l.Lng, l.Lat}
+		}
+		c.Features = append(c.Features, geoJSONFeature{
+			Type:     "Feature",
+			Geometry: geoJSONGeometry{Type: "LineString", Coordinates: line},
+			Properties: map[string]interface{}{
+				"start": t[0].Time.String(),
+				"end":   t[len(t)-1].Time.String(),
+			},
+		})
+	}
+	for _, l := range t {
+		c.Features = append(c.Features, geoJSONFeature{
+			Type:     "Feature",
+			Geometry: geoJSONGeometry{Type: "Point", Coordinates: [2]float64{l.Lng, l.Lat}},
+			Properties: map[string]interface{}{
+				"time":   l.Time.String(),
+				"radius": l.Radius,
+				"source": l.Source,
+			},
+		})
+	}
+	return json.Marshal(c)
+}
+
+type kmlDocument struct {
+	XMLName    xml.Name       `xml:"http://www.opengis.net/kml/2.2 kml"`
+	Name       string         `xml:"Document>name,omitempty"`
+	Placemarks []kmlPlacemark `xml:"Document>Placemark"`
+}
+
+type kmlPlacemark struct {
+	Name        string       `xml:"name,omitempty"`
+	Description string       `xml:"description,omitempty"`
+	TimeStamp   string       `xml:"TimeStamp>when,omitempty"`
+	Point       *kmlGeometry `xml:"Point,omitempty"`
+	LineString  *kmlGeometry `xml:"LineString,omitempty"`
+}
+
+type kmlGeometry struct {
+	Coordinates string `xml:"coordinates"`
+}
+
+func kmlCoordinates(l DeviceLocation) string {
+	return strconv.FormatFloat(l.Lng, 'f', -1, 64) + "," + strconv.FormatFloat(l.Lat, 'f', -1, 64)
+}
+
+// KML encodes the trajectory as a KML document of the given name: a LineString placemark
+// going through the locations, when there are at least two, followed by a Point placemark
+// per location stamped with its time.
+func (t Trajectory) KML(name string) ([]byte, error) {
+	doc := kmlDocument{Name: name}
+
+	if len(t) >= 2 {
+		coords := make([]string, len(t))
+		for i, l := range t {
+			coords[i] = kmlCoordinates(l)
+		}
+		doc.Placemarks = append(doc.Placemarks, kmlPlacemark{
+			Name:       "Trajectory",
+			LineString: &kmlGeometry{Coordinates: strings.Join(coords, " ")},
+		})
+	}
+	for _, l := range t {
+		doc.Placemarks = append(doc.Placemarks, kmlPlacemark{
+			Name:        l.Time.String(),
+			Description: "Radius: " + strconv.FormatFloat(l.Radius, 'f', -1, 64) + " m",
+			TimeStamp:   l.Time.String(),
+			Point:       &kmlGeometry{Coordinates: kmlCoordinates(l)},
+		})
+	}
+
+	b, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), b...), nil
+}
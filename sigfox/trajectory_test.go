@@ -0,0 +1,111 @@
+package sigfox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDeviceService_IterateLocations(t *testing.T) {
+	var server *httptest.Server
+	mux := http.NewServeMux()
+	mux.HandleFunc("/devices/d1/locations", func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.URL.Query().Get("since"), "1600000000000"; got != want {
+			t.Errorf("since = %q, want %q", got, want)
+		}
+		if r.URL.Query().Get("offset") == "" {
+			fmt.Fprintf(w, `{"data":[{"time":1600000003000,"valid":true,"lat":2,"lng":3,"radius":100,"source":2},
+				{"time":1600000002000,"valid":false}],
+				"paging":{"next":"%s/devices/d1/locations?since=1600000000000&offset=2"}}`, server.URL)
+			return
+		}
+		fmt.Fprint(w, `{"data":[{"time":1600000001000,"valid":true,"lat":1,"lng":2,"radius":50,"source":6,"placeIds":["p1"]}],"paging":{}}`)
+	})
+	server = httptest.NewServer(mux)
+	defer server.Close()
+
+	c, _ := NewClient("LOGIN_ID", "PASSWORD", WithBaseURL(server.URL))
+
+	var locations []DeviceLocation
+	it := c.Device.IterateLocations(context.Background(), "d1", nil, Since(1600000000000))
+	for it.Next() {
+		locations = append(locations, it.Value())
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("IterateLocations returned error: %v", err)
+	}
+
+	want := Trajectory{
+		{Time: 1600000001000, Valid: true, Lat: 1, Lng: 2, Radius: 50, Source: 6, PlaceIds: []string{"p1"}},
+		{Time: 1600000003000, Valid: true, Lat: 2, Lng: 3, Radius: 100, Source: 2},
+	}
+	if got := NewTrajectory(locations); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewTrajectory returned %+v, want %+v", got, want)
+	}
+}
+
+func TestTrajectory_GeoJSON(t *testing.T) {
+	tr := Trajectory{
+		{Time: 1600000001000, Valid: true, Lat: 1, Lng: 2, Radius: 50, Source: 6},
+		{Time: 1600000003000, Valid: true, Lat: 2.5, Lng: 3, Radius: 100, Source: 2},
+	}
+
+	b, err := tr.GeoJSON()
+	if err != nil {
+		t.Fatalf("GeoJSON returned error: %v", err)
+	}
+	var got map[string]interface{}
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatalf("GeoJSON returned invalid JSON: %v", err)
+	}
+
+	var want map[string]interface{}
+	json.Unmarshal([]byte(`{"type":"FeatureCollection","features":[
+		{"type":"Feature","geometry":{"type":"LineString","coordinates":[[2,1],[3,2.5]]},
+		 "properties":{"start":"2020-09-13T12:26:41.000Z","end":"2020-09-13T12:26:43.000Z"}},
+		{"type":"Feature","geometry":{"type":"Point","coordinates":[2,1]},
+		 "properties":{"time":"2020-09-13T12:26:41.000Z","radius":50,"source":6}},
+		{"type":"Feature","geometry":{"type":"Point","coordinates":[3,2.5]},
+		 "properties":{"time":"2020-09-13T12:26:43.000Z","radius":100,"source":2}}]}`), &want)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GeoJSON returned %s", b)
+	}
+
+	b, err = tr[:1].GeoJSON()
+	if err != nil {
+		t.Fatalf("GeoJSON returned error: %v", err)
+	}
+	if strings.Contains(string(b), "LineString") {
+		t.Errorf("GeoJSON of a single location returned a LineString: %s", b)
+	}
+}
+
+func TestTrajectory_KML(t *testing.T) {
+	tr := Trajectory{
+		{Time: 1600000001000, Valid: true, Lat: 1, Lng: 2, Radius: 50},
+		{Time: 1600000003000, Valid: true, Lat: 2.5, Lng: 3, Radius: 100},
+	}
+
+	b, err := tr.KML("d1")
+	if err != nil {
+		t.Fatalf("KML returned error: %v", err)
+	}
+	for _, want := range []string{
+		`<?xml version="1.0" encoding="UTF-8"?>`,
+		`<kml xmlns="http://www.opengis.net/kml/2.2">`,
+		`<name>d1</name>`,
+		`<coordinates>2,1 3,2.5</coordinates>`,
+		`<when>2020-09-13T12:26:41.000Z</when>`,
+		`<coordinates>3,2.5</coordinates>`,
+		`<description>Radius: 100 m</description>`,
+	} {
+		if !strings.Contains(string(b), want) {
+			t.Errorf("KML does not contain %s:\n%s", want, b)
+		}
+	}
+}
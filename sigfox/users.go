@@ -0,0 +1,232 @@
+package sigfox
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+type UserService service
+
+// User is a human user of the Sigfox portal.
+type User struct {
+	ID              string     `json:"id,omitempty"`
+	FirstName       string     `json:"firstName,omitempty"`
+	LastName        string     `json:"lastName,omitempty"`
+	Email           string     `json:"email,omitempty"`
+	Timezone        string     `json:"timezone,omitempty"`
+	Locked          bool       `json:"locked,omitempty"`
+	UserRoles       []UserRole `json:"userRoles,omitempty"`
+	CreationTime    Timestamp  `json:"creationTime,omitempty"`
+	CreatedBy       string     `json:"createdBy,omitempty"`
+	LastEditionTime Timestamp  `json:"lastEditionTime,omitempty"`
+	LastEditedBy    string     `json:"lastEditedBy,omitempty"`
+}
+
+// UserRole is a profile assigned to a user in a group.
+type UserRole struct {
+	Group   MinimalGroup `json:"group,omitempty"`
+	Profile Profile      `json:"profile,omitempty"`
+}
+
+// UserRoleInput assigns a profile to a user in a group.
+type UserRoleInput struct {
+	GroupID   string `json:"groupId"`
+	ProfileID string `json:"profileId"`
+}
+
+type ListUsersOutput struct {
+	Data   []User     `json:"data"`
+	Paging Pagination `json:"paging"`
+}
+
+// ListWithResponse retrieve a list of users according to visibility permissions and request filters.
+// The users can be filtered with the GroupIds and ProfileID params.
+func (s *UserService) ListWithResponse(params ...QueryParam) (*ListUsersOutput, *Response, error) {
+	return s.ListWithResponseContext(context.Background(), params...)
+}
+
+// ListWithResponseContext retrieve a list of users according to visibility permissions and request filters with context.
+func (s *UserService) ListWithResponseContext(ctx context.Context, params ...QueryParam) (*ListUsersOutput, *Response, error) {
+	opt := &QueryParams{}
+	for _, param := range params {
+		param(opt)
+	}
+
+	spath, err := addOptions("/users", opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var out ListUsersOutput
+	res, err := s.client.call(ctx, "GET", spath, nil, &out)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return &out, res, nil
+}
+
+// InfoWithResponse retrieve information about a user.
+func (s *UserService) InfoWithResponse(userID string, params ...QueryParam) (*User, *Response, error) {
+	return s.InfoWithResponseContext(context.Background(), userID, params...)
+}
+
+// InfoWithResponseContext retrieve information about a user with context.
+func (s *UserService) InfoWithResponseContext(ctx context.Context, userID string, params ...QueryParam) (*User, *Response, error) {
+	opt := &QueryParams{}
+	for _, param := range params {
+		param(opt)
+	}
+
+	spath := fmt.Sprintf("/users/%s", userID)
+	spath, err := addOptions(spath, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var out User
+	res, err := s.client.call(ctx, "GET", spath, nil, &out)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return &out, res, nil
+}
+
+type CreateUserInput struct {
+	FirstName string          `json:"firstName"`
+	LastName  string          `json:"lastName"`
+	Email     string          `json:"email"`
+	Timezone  string          `json:"timezone"`
+	UserRoles []UserRoleInput `json:"userRoles"`
+}
+
+// Validate checks the values of the input.
+func (input *CreateUserInput) Validate() error {
+	var errs fieldErrors
+	if input.FirstName == "" {
+		errs.add("firstName", "first name is required")
+	}
+	if input.LastName == "" {
+		errs.add("lastName", "last name is required")
+	}
+	if input.Email == "" {
+		errs.add("email", "email is required")
+	}
+	if input.Timezone == "" {
+		errs.add("timezone", "timezone is required")
+	}
+	if len(input.UserRoles) == 0 {
+		errs.add("userRoles", "at least one profile is required")
+	}
+	validateUserRoles(&errs, input.UserRoles)
+	return errs.err()
+}
+
+func validateUserRoles(errs *fieldErrors, roles []UserRoleInput) {
+	for i, r := range roles {
+		if r.GroupID == "" {
+			errs.add(fmt.Sprintf("userRoles[%d].groupId", i), "group ID is required")
+		}
+		if r.ProfileID == "" {
+			errs.add(fmt.Sprintf("userRoles[%d].profileId", i), "profile ID is required")
+		}
+	}
+}
+
+type CreateUserOutput struct {
+	ID string `json:"id,omitempty"`
+}
+
+// CreateWithResponse create a new user.
+func (s *UserService) CreateWithResponse(input *CreateUserInput) (*CreateUserOutput, *Response, error) {
+	return s.CreateWithResponseContext(context.Background(), input)
+}
+
+// CreateWithResponseContext create a new user with context.
+func (s *UserService) CreateWithResponseContext(ctx context.Context, input *CreateUserInput) (*CreateUserOutput, *Response, error) {
+	if err := input.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	var out CreateUserOutput
+	res, err := s.client.call(ctx, "POST", "/users", input, &out)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return &out, res, nil
+}
+
+type UpdateUserInput struct {
+	FirstName string `json:"firstName,omitempty"`
+	LastName  string `json:"lastName,omitempty"`
+	Timezone  string `json:"timezone,omitempty"`
+}
+
+// UpdateWithResponse update a user.
+func (s *UserService) UpdateWithResponse(userID string, input *UpdateUserInput) (*Response, error) {
+	return s.UpdateWithResponseContext(context.Background(), userID, input)
+}
+
+// UpdateWithResponseContext update a user with context.
+func (s *UserService) UpdateWithResponseContext(ctx context.Context, userID string, input *UpdateUserInput) (*Response, error) {
+	spath := fmt.Sprintf("/users/%s", userID)
+	return s.client.call(ctx, "PUT", spath, input, nil)
+}
+
+// DeleteWithResponse delete a user.
+func (s *UserService) DeleteWithResponse(userID string) (*Response, error) {
+	return s.DeleteWithResponseContext(context.Background(), userID)
+}
+
+// DeleteWithResponseContext delete a user with context.
+func (s *UserService) DeleteWithResponseContext(ctx context.Context, userID string) (*Response, error) {
+	spath := fmt.Sprintf("/users/%s", userID)
+	return s.client.call(ctx, "DELETE", spath, nil, nil)
+}
+
+type AddUserProfilesInput struct {
+	UserRoles []UserRoleInput `json:"userRoles"`
+}
+
+// Validate checks the values of the input.
+func (input *AddUserProfilesInput) Validate() error {
+	var errs fieldErrors
+	if len(input.UserRoles) == 0 {
+		errs.add("userRoles", "at least one profile is required")
+	}
+	validateUserRoles(&errs, input.UserRoles)
+	return errs.err()
+}
+
+// AddProfilesWithResponse assign profiles to a user in groups.
+func (s *UserService) AddProfilesWithResponse(userID string, input *AddUserProfilesInput) (*Response, error) {
+	return s.AddProfilesWithResponseContext(context.Background(), userID, input)
+}
+
+// AddProfilesWithResponseContext assign profiles to a user in groups with context.
+func (s *UserService) AddProfilesWithResponseContext(ctx context.Context, userID string, input *AddUserProfilesInput) (*Response, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	spath := fmt.Sprintf("/users/%s/profiles", userID)
+	return s.client.call(ctx, "PUT", spath, input, nil)
+}
+
+// RemoveProfileWithResponse remove a profile of a user in a group.
+// An empty profile ID removes all the profiles of the user in the group.
+func (s *UserService) RemoveProfileWithResponse(userID, groupID, profileID string) (*Response, error) {
+	return s.RemoveProfileWithResponseContext(context.Background(), userID, groupID, profileID)
+}
+
+// RemoveProfileWithResponseContext remove a profile of a user in a group with context.
+func (s *UserService) RemoveProfileWithResponseContext(ctx context.Context, userID, groupID, profileID string) (*Response, error) {
+	spath := fmt.Sprintf("/users/%s/profiles/%s", userID, groupID)
+	if profileID != "" {
+		spath += "?profileId=" + url.QueryEscape(profileID)
+	}
+	return s.client.call(ctx, "DELETE", spath, nil, nil)
+}
@@ -0,0 +1,92 @@
+package sigfox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestUserService_List(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/users", func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.URL.RawQuery, "groupIds=g1&groupIds=g2&profileId=p1"; got != want {
+			t.Errorf("query = %q, want %q", got, want)
+		}
+		fmt.Fprint(w, `{"data":[{"id":"u1","firstName":"Jane","lastName":"Doe","email":"jane@example.com",
+			"userRoles":[{"group":{"id":"g1","name":"Group","type":2,"level":1},"profile":{"id":"p1","name":"Admin"}}]}],"paging":{}}`)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	c, _ := NewClient("LOGIN_ID", "PASSWORD", WithBaseURL(server.URL))
+
+	out, _, err := c.User.ListWithResponseContext(context.Background(), GroupIds([]string{"g1", "g2"}), ProfileID("p1"))
+	if err != nil {
+		t.Fatalf("ListContext returned error: %v", err)
+	}
+
+	want := []User{{
+		ID:        "u1",
+		FirstName: "Jane",
+		LastName:  "Doe",
+		Email:     "jane@example.com",
+		UserRoles: []UserRole{{
+			Group:   MinimalGroup{ID: "g1", Name: "Group", Type: GroupTypeOther, Level: 1},
+			Profile: Profile{ID: "p1", Name: "Admin"},
+		}},
+	}}
+	if !reflect.DeepEqual(out.Data, want) {
+		t.Errorf("ListContext returned %+v, want %+v", out.Data, want)
+	}
+}
+
+func TestUserService_RemoveProfile(t *testing.T) {
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	c, _ := NewClient("LOGIN_ID", "PASSWORD", WithBaseURL(server.URL))
+	ctx := context.Background()
+
+	if _, err := c.User.RemoveProfileWithResponseContext(ctx, "u1", "g1", "p 1"); err != nil {
+		t.Fatalf("RemoveProfileContext returned error: %v", err)
+	}
+	if _, err := c.User.RemoveProfileWithResponseContext(ctx, "u1", "g1", ""); err != nil {
+		t.Fatalf("RemoveProfileContext returned error: %v", err)
+	}
+	if want := []string{"profileId=p+1", ""}; !reflect.DeepEqual(queries, want) {
+		t.Errorf("queries = %q, want %q", queries, want)
+	}
+}
+
+func TestCreateUserInput_Validate(t *testing.T) {
+	input := &CreateUserInput{
+		FirstName: "Jane",
+		Email:     "jane@example.com",
+		Timezone:  "Europe/Paris",
+		UserRoles: []UserRoleInput{{GroupID: "g1"}},
+	}
+
+	err := input.Validate()
+	if !errors.Is(err, ErrValidation) {
+		t.Fatalf("Validate returned %v, want a validation error", err)
+	}
+	var ierr *InvalidInputError
+	if !errors.As(err, &ierr) {
+		t.Fatalf("Validate returned %T, want *InvalidInputError", err)
+	}
+	var fields []string
+	for _, e := range ierr.Errors {
+		fields = append(fields, e.Field)
+	}
+	if want := []string{"lastName", "userRoles[0].profileId"}; !reflect.DeepEqual(fields, want) {
+		t.Errorf("Validate reported %v, want %v", fields, want)
+	}
+}